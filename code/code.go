@@ -18,6 +18,11 @@ const (
 	ActorSourceEqualTarget  int32 = 30 // source equal target
 	ActorPublishRemoteError int32 = 31 // actor publish remote error
 	ActorChildIDNotFound    int32 = 32 // actor child id not found
+	ActorMailboxFull        int32 = 33 // actor mailbox is full
+	ActorLocationNotFound   int32 = 34 // actor location not found
+	ActorCallDeadlock       int32 = 35 // actor call wait chain is deadlock
+	ActorStopping           int32 = 36 // actor is stopping
+	ActorCallTimeout        int32 = 37 // actor call wait timeout
 )
 
 func IsOK(code int32) bool {
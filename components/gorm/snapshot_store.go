@@ -0,0 +1,78 @@
+package cherryGORM
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	snapshotTableName = "actor_snapshot"
+)
+
+type (
+	// SnapshotStore 基于gorm的actor状态快照存储(实现cactor.ISnapshotStore)
+	SnapshotStore struct {
+		db    *gorm.DB
+		table string
+	}
+
+	// ActorSnapshot actor状态快照表
+	ActorSnapshot struct {
+		ActorID   string `gorm:"column:actor_id;primaryKey;size:128"`
+		Data      []byte `gorm:"column:data"`
+		UpdatedAt int64  `gorm:"column:updated_at"`
+	}
+)
+
+// NewSnapshotStore 创建快照存储,表不存在时自动创建
+func NewSnapshotStore(db *gorm.DB, table ...string) (*SnapshotStore, error) {
+	store := &SnapshotStore{
+		db:    db,
+		table: snapshotTableName,
+	}
+
+	if len(table) > 0 && table[0] != "" {
+		store.table = table[0]
+	}
+
+	if err := db.Table(store.table).AutoMigrate(&ActorSnapshot{}); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (p *SnapshotStore) Save(actorID string, data []byte) error {
+	snapshot := &ActorSnapshot{
+		ActorID:   actorID,
+		Data:      data,
+		UpdatedAt: time.Now().Unix(),
+	}
+
+	return p.db.Table(p.table).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "actor_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "updated_at"}),
+	}).Create(snapshot).Error
+}
+
+func (p *SnapshotStore) Load(actorID string) ([]byte, error) {
+	snapshot := &ActorSnapshot{}
+
+	err := p.db.Table(p.table).Where("actor_id = ?", actorID).Take(snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.Data, nil
+}
+
+func (p *SnapshotStore) Delete(actorID string) error {
+	return p.db.Table(p.table).Where("actor_id = ?", actorID).Delete(&ActorSnapshot{}).Error
+}
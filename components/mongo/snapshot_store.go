@@ -0,0 +1,83 @@
+package cherryMongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	snapshotCollectionName = "actor_snapshot"
+)
+
+type (
+	// SnapshotStore 基于mongo的actor状态快照存储(实现cactor.ISnapshotStore)
+	SnapshotStore struct {
+		collection *mongo.Collection
+		timeout    time.Duration
+	}
+
+	// ActorSnapshot actor状态快照文档
+	ActorSnapshot struct {
+		ActorID   string `bson:"_id"`
+		Data      []byte `bson:"data"`
+		UpdatedAt int64  `bson:"updated_at"`
+	}
+)
+
+// NewSnapshotStore 创建快照存储
+func NewSnapshotStore(db *mongo.Database, collection ...string) *SnapshotStore {
+	name := snapshotCollectionName
+	if len(collection) > 0 && collection[0] != "" {
+		name = collection[0]
+	}
+
+	return &SnapshotStore{
+		collection: db.Collection(name),
+		timeout:    3 * time.Second,
+	}
+}
+
+func (p *SnapshotStore) Save(actorID string, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	snapshot := &ActorSnapshot{
+		ActorID:   actorID,
+		Data:      data,
+		UpdatedAt: time.Now().Unix(),
+	}
+
+	_, err := p.collection.ReplaceOne(ctx, bson.M{"_id": actorID}, snapshot, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (p *SnapshotStore) Load(actorID string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	snapshot := &ActorSnapshot{}
+
+	err := p.collection.FindOne(ctx, bson.M{"_id": actorID}).Decode(snapshot)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.Data, nil
+}
+
+func (p *SnapshotStore) Delete(actorID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	_, err := p.collection.DeleteOne(ctx, bson.M{"_id": actorID})
+	return err
+}
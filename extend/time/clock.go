@@ -0,0 +1,342 @@
+package cherryTime
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// Clock 时钟,ctime.Now()、时间轮、actor定时器及agent心跳检查都通过当前时钟获取时间
+	Clock interface {
+		Now() time.Time                         // 当前时间
+		After(d time.Duration) <-chan time.Time // d时间后触发
+		NewTicker(d time.Duration) *Ticker      // 每隔d时间触发
+	}
+
+	// Ticker 时钟的周期触发器
+	Ticker struct {
+		C    <-chan time.Time
+		stop func()
+	}
+
+	// RealClock 系统时钟
+	RealClock struct{}
+
+	// OffsetClock 在系统时钟上增加偏移时间,用于测试服由GM调整时间(如模拟跨天)
+	// 偏移变化时唤醒所有After()的等待方,并执行OnSetClock()注册的函数,让时间轮重新计算到期的定时器.
+	// 已添加的定时器保持原到期时间(游戏时间):偏移前进时到期的定时器立即执行,偏移后退时等游戏时间再次到达才执行.
+	// NewTicker()按系统时间的间隔触发,不受偏移影响
+	OffsetClock struct {
+		sync.Mutex
+		offset  int64                      // time.Duration
+		waiters map[*offsetWaiter]struct{} // After()的等待方
+	}
+
+	offsetWaiter struct {
+		timer *time.Timer
+		c     chan time.Time
+	}
+
+	// ManualClock 手动时钟,用于单元测试.时间只在Advance()/Set()时前进,
+	// 并在调用方goroutine中同步执行到期的定时器
+	ManualClock struct {
+		sync.Mutex
+		now     time.Time
+		waiters []*manualWaiter
+	}
+
+	manualWaiter struct {
+		deadline time.Time
+		interval time.Duration // >0为Ticker
+		c        chan time.Time
+		stopped  bool
+	}
+
+	clockHook struct {
+		fn func(now time.Time)
+	}
+)
+
+var (
+	clockValue    atomic.Value // Clock
+	hookMutex     sync.RWMutex
+	advanceHooks  []*clockHook // 手动时钟前进时同步执行
+	setClockHooks []*clockHook // 切换时钟后同步执行
+)
+
+func init() {
+	SetClock(RealClock{})
+}
+
+// SetClock 设置全局时钟
+func SetClock(clock Clock) {
+	if clock == nil {
+		clock = RealClock{}
+	}
+
+	clockValue.Store(&clock)
+
+	runHooks(&setClockHooks, clock.Now())
+}
+
+// GetClock 获取全局时钟
+func GetClock() Clock {
+	return *clockValue.Load().(*Clock)
+}
+
+// OnAdvance 注册手动时钟前进时执行的函数(如时间轮同步执行到期的定时器),返回注销函数
+func OnAdvance(fn func(now time.Time)) (cancel func()) {
+	return addHook(&advanceHooks, fn)
+}
+
+// OnSetClock 注册切换时钟或调整偏移时间后执行的函数(如时间轮同步当前时间,新的时间可能回退),返回注销函数
+func OnSetClock(fn func(now time.Time)) (cancel func()) {
+	return addHook(&setClockHooks, fn)
+}
+
+func addHook(list *[]*clockHook, fn func(now time.Time)) func() {
+	hook := &clockHook{fn: fn}
+
+	hookMutex.Lock()
+	*list = append(*list, hook)
+	hookMutex.Unlock()
+
+	return func() {
+		hookMutex.Lock()
+		defer hookMutex.Unlock()
+
+		hooks := make([]*clockHook, 0, len(*list))
+		for _, h := range *list {
+			if h != hook {
+				hooks = append(hooks, h)
+			}
+		}
+		*list = hooks
+	}
+}
+
+func runHooks(list *[]*clockHook, now time.Time) {
+	hookMutex.RLock()
+	hooks := *list
+	hookMutex.RUnlock()
+
+	for _, hook := range hooks {
+		hook.fn(now)
+	}
+}
+
+// Stop 停止触发
+func (t *Ticker) Stop() {
+	t.stop()
+}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+func (RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (RealClock) NewTicker(d time.Duration) *Ticker {
+	ticker := time.NewTicker(d)
+	return &Ticker{
+		C:    ticker.C,
+		stop: ticker.Stop,
+	}
+}
+
+func NewOffsetClock() *OffsetClock {
+	return &OffsetClock{
+		waiters: make(map[*offsetWaiter]struct{}),
+	}
+}
+
+// SetOffset 设置偏移时间
+func (c *OffsetClock) SetOffset(offset time.Duration) {
+	c.Lock()
+	atomic.StoreInt64(&c.offset, int64(offset))
+	waiters := c.waiters
+	c.waiters = make(map[*offsetWaiter]struct{})
+	c.Unlock()
+
+	now := c.Now()
+	for waiter := range waiters {
+		waiter.timer.Stop()
+		waiter.c <- now
+	}
+
+	runHooks(&setClockHooks, now)
+}
+
+// AddOffset 增加偏移时间
+func (c *OffsetClock) AddOffset(d time.Duration) {
+	c.SetOffset(c.Offset() + d)
+}
+
+// Offset 当前偏移时间
+func (c *OffsetClock) Offset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.offset))
+}
+
+func (c *OffsetClock) Now() time.Time {
+	return time.Now().Add(c.Offset())
+}
+
+// After d时间后或偏移变化时触发.调用方不再等待时,不会残留goroutine
+func (c *OffsetClock) After(d time.Duration) <-chan time.Time {
+	waiter := &offsetWaiter{
+		c: make(chan time.Time, 1),
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if c.waiters == nil {
+		c.waiters = make(map[*offsetWaiter]struct{})
+	}
+	c.waiters[waiter] = struct{}{}
+	waiter.timer = time.AfterFunc(d, func() {
+		c.fire(waiter)
+	})
+
+	return waiter.c
+}
+
+func (c *OffsetClock) fire(waiter *offsetWaiter) {
+	c.Lock()
+	_, found := c.waiters[waiter]
+	delete(c.waiters, waiter)
+	c.Unlock()
+
+	// 已被SetOffset()唤醒
+	if !found {
+		return
+	}
+
+	waiter.c <- c.Now()
+}
+
+func (c *OffsetClock) NewTicker(d time.Duration) *Ticker {
+	return RealClock{}.NewTicker(d)
+}
+
+// NewManualClock 创建手动时钟,start为零值时从当前时间开始
+func NewManualClock(start time.Time) *ManualClock {
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	return &ManualClock{
+		now: start,
+	}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.now
+}
+
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	return c.addWaiter(d, 0).c
+}
+
+func (c *ManualClock) NewTicker(d time.Duration) *Ticker {
+	waiter := c.addWaiter(d, d)
+
+	return &Ticker{
+		C: waiter.c,
+		stop: func() {
+			c.Lock()
+			defer c.Unlock()
+
+			waiter.stopped = true
+		},
+	}
+}
+
+func (c *ManualClock) addWaiter(d, interval time.Duration) *manualWaiter {
+	c.Lock()
+	defer c.Unlock()
+
+	waiter := &manualWaiter{
+		deadline: c.now.Add(d),
+		interval: interval,
+		c:        make(chan time.Time, 1),
+	}
+
+	if d <= 0 && interval <= 0 {
+		waiter.c <- c.now
+		return waiter
+	}
+
+	c.waiters = append(c.waiters, waiter)
+
+	return waiter
+}
+
+// Advance 时间前进d,同步执行到期的定时器
+func (c *ManualClock) Advance(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	c.Set(c.Now().Add(d))
+}
+
+// Set 设置当前时间(只能前进)
+func (c *ManualClock) Set(now time.Time) {
+	c.Lock()
+	if !now.After(c.now) {
+		c.Unlock()
+		return
+	}
+
+	c.now = now
+	waiters := c.fireWaiters(now)
+	c.Unlock()
+
+	runHooks(&advanceHooks, now)
+
+	// 时间轮处理完到期定时器后再唤醒等待方
+	for _, waiter := range waiters {
+		select {
+		case waiter.c <- now:
+		default:
+		}
+	}
+}
+
+// fireWaiters 返回到期的等待方,Ticker计算下次触发时间
+func (c *ManualClock) fireWaiters(now time.Time) []*manualWaiter {
+	var (
+		fired   []*manualWaiter
+		pending = c.waiters[:0]
+	)
+
+	for _, waiter := range c.waiters {
+		if waiter.stopped {
+			continue
+		}
+
+		if waiter.deadline.After(now) {
+			pending = append(pending, waiter)
+			continue
+		}
+
+		fired = append(fired, waiter)
+		if waiter.interval > 0 {
+			for !waiter.deadline.After(now) {
+				waiter.deadline = waiter.deadline.Add(waiter.interval)
+			}
+			pending = append(pending, waiter)
+		}
+	}
+
+	c.waiters = pending
+
+	return fired
+}
@@ -0,0 +1,118 @@
+package cherryTime
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestManualClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+
+	after := clock.After(time.Minute)
+	ticker := clock.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	clock.Advance(30 * time.Second)
+
+	select {
+	case <-after:
+		t.Fatal("after fired too early")
+	default:
+	}
+
+	select {
+	case <-ticker.C:
+	default:
+		t.Fatal("ticker not fired")
+	}
+
+	clock.Advance(30 * time.Second)
+	if now := <-after; !now.Equal(start.Add(time.Minute)) {
+		t.Fatalf("now = %v", now)
+	}
+
+	SetClock(clock)
+	defer SetClock(nil)
+
+	if !Now().Time.Equal(start.Add(time.Minute)) {
+		t.Fatalf("ctime.Now = %v", Now())
+	}
+}
+
+func TestOffsetClock(t *testing.T) {
+	clock := NewOffsetClock()
+	after := clock.After(time.Hour)
+
+	clock.AddOffset(24 * time.Hour)
+
+	select {
+	case <-after:
+	case <-time.After(time.Second):
+		t.Fatal("after not woken by offset change")
+	}
+
+	if d := clock.Now().Sub(time.Now()); d < 23*time.Hour {
+		t.Fatalf("offset = %v", d)
+	}
+}
+
+func TestAddOffsetTime(t *testing.T) {
+	defer SetClock(nil)
+
+	AddOffsetTime(24 * time.Hour)
+
+	// 偏移时间通过时钟生效,时间轮及actor定时器与ctime.Now()一致
+	clockNow := GetClock().Now()
+	if diff := clockNow.Sub(time.Now()); diff < 23*time.Hour {
+		t.Fatalf("clock offset = %v", diff)
+	}
+
+	if diff := Now().Sub(clockNow); diff < 0 || diff > time.Second {
+		t.Fatalf("ctime.Now diff = %v", diff)
+	}
+}
+
+func TestOnAdvanceCancel(t *testing.T) {
+	clock := NewManualClock(time.Time{})
+
+	var count int
+	cancel := OnAdvance(func(_ time.Time) {
+		count++
+	})
+
+	clock.Advance(time.Second)
+	cancel()
+	clock.Advance(time.Second)
+
+	if count != 1 {
+		t.Fatalf("count = %d", count)
+	}
+}
+
+func TestOffsetClockAfterNoLeak(t *testing.T) {
+	clock := NewOffsetClock()
+	before := runtime.NumGoroutine()
+
+	// 调用方不再等待,不残留goroutine
+	for i := 0; i < 100; i++ {
+		clock.After(time.Hour)
+	}
+
+	if n := runtime.NumGoroutine(); n > before {
+		t.Fatalf("goroutines = %d, before = %d", n, before)
+	}
+
+	after := clock.After(10 * time.Millisecond)
+	select {
+	case <-after:
+	case <-time.After(time.Second):
+		t.Fatal("after not fired")
+	}
+
+	clock.SetOffset(time.Hour)
+	if len(clock.waiters) != 0 {
+		t.Fatalf("waiters = %d", len(clock.waiters))
+	}
+}
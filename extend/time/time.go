@@ -55,7 +55,7 @@ func NewTime(tt time.Time, setGlobal bool) CherryTime {
 	ct := CherryTime{}
 
 	if setGlobal {
-		ct.Time = tt.In(offsetLocation).Add(offsetTime)
+		ct.Time = tt.In(offsetLocation)
 	} else {
 		ct.Time = tt
 	}
@@ -72,11 +72,11 @@ func NewMillisecond(millisecond int64) CherryTime {
 }
 
 func Now() CherryTime {
-	return NewTime(time.Now(), true)
+	return NewTime(GetClock().Now(), true)
 }
 
 func Yesterday() CherryTime {
-	t := time.Now().AddDate(0, 0, -1)
+	t := GetClock().Now().AddDate(0, 0, -1)
 	return NewTime(t, true)
 }
 
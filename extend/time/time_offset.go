@@ -3,7 +3,6 @@ package cherryTime
 import "time"
 
 var (
-	offsetTime     time.Duration  //全局偏移时间
 	offsetLocation *time.Location //全局偏移时区
 )
 
@@ -11,12 +10,26 @@ func init() {
 	SetOffsetLocation("Local")
 }
 
+// AddOffsetTime 设置全局偏移时间
+// 偏移通过OffsetClock实现,ctime.Now()、时间轮及actor定时器使用相同的时间.
+// 当前时钟不是OffsetClock时(如ManualClock),将被替换为OffsetClock
 func AddOffsetTime(t time.Duration) {
-	offsetTime = t
+	setOffsetTime(t)
 }
 
 func SubOffsetTime(t time.Duration) {
-	offsetTime = -t
+	setOffsetTime(-t)
+}
+
+func setOffsetTime(t time.Duration) {
+	if clock, ok := GetClock().(*OffsetClock); ok {
+		clock.SetOffset(t)
+		return
+	}
+
+	clock := NewOffsetClock()
+	clock.SetOffset(t)
+	SetClock(clock)
 }
 
 func SetOffsetLocation(name string) (err error) {
@@ -27,3 +40,8 @@ func SetOffsetLocation(name string) (err error) {
 
 	return nil
 }
+
+// OffsetLocation 全局偏移时区
+func OffsetLocation() *time.Location {
+	return offsetLocation
+}
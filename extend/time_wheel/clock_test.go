@@ -0,0 +1,111 @@
+package cherryTimeWheel
+
+import (
+	"testing"
+	"time"
+
+	ctime "github.com/cherry-game/cherry/extend/time"
+)
+
+func TestManualClockAdvance(t *testing.T) {
+	clock := ctime.NewManualClock(time.Time{})
+	ctime.SetClock(clock)
+	defer ctime.SetClock(nil)
+
+	tw := NewTimeWheel(10*time.Millisecond, 60)
+	tw.Start()
+	defer tw.Stop()
+
+	var once, every int
+	tw.AfterFunc(NextID(), time.Hour, func() { once++ })
+	tw.AddEveryFunc(NextID(), 10*time.Minute, func() { every++ })
+
+	clock.Advance(30 * time.Minute)
+	if once != 0 || every != 3 {
+		t.Fatalf("once = %d, every = %d", once, every)
+	}
+
+	clock.Advance(30 * time.Minute)
+	if once != 1 || every != 6 {
+		t.Fatalf("once = %d, every = %d", once, every)
+	}
+}
+
+func TestManualClockRestore(t *testing.T) {
+	tw := NewTimeWheel(10*time.Millisecond, 60)
+	tw.Start()
+	defer tw.Stop()
+
+	clock := ctime.NewManualClock(time.Time{})
+	ctime.SetClock(clock)
+	clock.Advance(time.Hour)
+	ctime.SetClock(nil)
+
+	// 恢复系统时钟后,时间轮不能停留在手动时钟的时间
+	fired := make(chan struct{}, 1)
+	tw.AfterFunc(NextID(), 2*time.Second, func() {
+		fired <- struct{}{}
+	})
+
+	select {
+	case <-fired:
+		t.Fatal("timer fired immediately after the clock is restored")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	tw.AfterFunc(NextID(), 20*time.Millisecond, func() {
+		fired <- struct{}{}
+	})
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("timer not fired")
+	}
+}
+
+func TestOffsetClockBackward(t *testing.T) {
+	clock := ctime.NewOffsetClock()
+	ctime.SetClock(clock)
+	defer ctime.SetClock(nil)
+
+	tw := NewTimeWheel(10*time.Millisecond, 60)
+	tw.Start()
+	defer tw.Stop()
+
+	scheduled := make(chan struct{}, 1)
+	tw.AfterFunc(NextID(), 100*time.Millisecond, func() {
+		scheduled <- struct{}{}
+	})
+
+	clock.AddOffset(-time.Hour)
+
+	// 偏移后退后,新添加的定时器按新的时间计算,不能立即执行
+	fired := make(chan struct{}, 1)
+	tw.AfterFunc(NextID(), 2*time.Second, func() {
+		fired <- struct{}{}
+	})
+
+	tw.AfterFunc(NextID(), 20*time.Millisecond, func() {
+		fired <- struct{}{}
+	})
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("timer not fired")
+	}
+
+	select {
+	case <-fired:
+		t.Fatal("timer fired immediately after the offset moved backward")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// 已添加的定时器保持原到期时间(游戏时间),等游戏时间再次到达才执行
+	select {
+	case <-scheduled:
+		t.Fatal("scheduled timer fired before the game time reached")
+	default:
+	}
+}
@@ -0,0 +1,313 @@
+package cherryTimeWheel
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	cerr "github.com/cherry-game/cherry/error"
+)
+
+const (
+	cronTimeZonePrefix = "CRON_TZ="
+	cronYearLimit      = 5 // 超过5年仍未匹配则认为没有下次触发时间
+)
+
+type (
+	// CronSchedule cron表达式调度
+	//
+	// 格式: 秒 分 时 日 月 周(也支持省略秒的5段格式)
+	// 支持 * ? , - / 及月份(JAN-DEC)、星期(SUN-SAT)的英文缩写,星期的0和7都表示周日
+	// 支持 @yearly @monthly @weekly @daily @hourly,以及 CRON_TZ=Asia/Shanghai 前缀指定时区
+	CronSchedule struct {
+		second, minute, hour, dom, month, dow uint64
+		domStar, dowStar                      bool // 日、周字段为*或?
+		location                              *time.Location
+	}
+
+	cronBounds struct {
+		min, max uint
+		names    map[string]uint
+	}
+)
+
+var (
+	cronSeconds = cronBounds{0, 59, nil}
+	cronMinutes = cronBounds{0, 59, nil}
+	cronHours   = cronBounds{0, 23, nil}
+	cronDom     = cronBounds{1, 31, nil}
+	cronMonths  = cronBounds{1, 12, map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDow = cronBounds{0, 7, map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 0 1 1 *",
+		"@annually": "0 0 0 1 1 *",
+		"@monthly":  "0 0 0 1 * *",
+		"@weekly":   "0 0 0 * * 0",
+		"@daily":    "0 0 0 * * *",
+		"@midnight": "0 0 0 * * *",
+		"@hourly":   "0 0 * * * *",
+	}
+)
+
+// ParseCron 解析cron表达式
+// loc 表达式未指定CRON_TZ时使用的时区,为nil时使用time.Local
+func ParseCron(spec string, loc *time.Location) (*CronSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	if loc == nil {
+		loc = time.Local
+	}
+
+	if strings.HasPrefix(spec, cronTimeZonePrefix) {
+		i := strings.Index(spec, " ")
+		if i < 0 {
+			return nil, cerr.Errorf("Cron spec error. [spec = %s]", spec)
+		}
+
+		var err error
+		if loc, err = time.LoadLocation(spec[len(cronTimeZonePrefix):i]); err != nil {
+			return nil, cerr.Errorf("Cron time zone error. [spec = %s, err = %v]", spec, err)
+		}
+
+		spec = strings.TrimSpace(spec[i:])
+	}
+
+	if descriptor, found := cronDescriptors[strings.ToLower(spec)]; found {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) == 5 {
+		fields = append([]string{"0"}, fields...)
+	}
+
+	if len(fields) != 6 {
+		return nil, cerr.Errorf("Cron spec must have 5 or 6 fields. [spec = %s]", spec)
+	}
+
+	s := &CronSchedule{
+		location: loc,
+		domStar:  isCronStar(fields[3]),
+		dowStar:  isCronStar(fields[5]),
+	}
+
+	var err error
+	for i, item := range []struct {
+		bits   *uint64
+		bounds cronBounds
+	}{
+		{&s.second, cronSeconds},
+		{&s.minute, cronMinutes},
+		{&s.hour, cronHours},
+		{&s.dom, cronDom},
+		{&s.month, cronMonths},
+		{&s.dow, cronDow},
+	} {
+		if *item.bits, err = parseCronField(fields[i], item.bounds); err != nil {
+			return nil, cerr.Errorf("Cron spec error. [spec = %s, err = %v]", spec, err)
+		}
+	}
+
+	// 周日可以用7表示
+	if s.dow&(1<<7) > 0 {
+		s.dow |= 1
+	}
+
+	return s, nil
+}
+
+func isCronStar(field string) bool {
+	return field == "*" || field == "?"
+}
+
+func parseCronField(field string, bounds cronBounds) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		value, err := parseCronRange(expr, bounds)
+		if err != nil {
+			return 0, err
+		}
+		bits |= value
+	}
+
+	return bits, nil
+}
+
+// parseCronRange 解析 *, ?, n, n-m, */step, n/step, n-m/step
+func parseCronRange(expr string, bounds cronBounds) (uint64, error) {
+	var (
+		start, end, step uint = 0, 0, 1
+		err              error
+	)
+
+	rangeAndStep := strings.Split(expr, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, cerr.Errorf("too many slashes: %s", expr)
+	}
+
+	lowAndHigh := strings.Split(rangeAndStep[0], "-")
+	switch {
+	case isCronStar(rangeAndStep[0]):
+		start, end = bounds.min, bounds.max
+	case len(lowAndHigh) == 1:
+		if start, err = parseCronValue(lowAndHigh[0], bounds); err != nil {
+			return 0, err
+		}
+		end = start
+		if len(rangeAndStep) == 2 {
+			end = bounds.max
+		}
+	case len(lowAndHigh) == 2:
+		if start, err = parseCronValue(lowAndHigh[0], bounds); err != nil {
+			return 0, err
+		}
+		if end, err = parseCronValue(lowAndHigh[1], bounds); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, cerr.Errorf("too many hyphens: %s", expr)
+	}
+
+	if len(rangeAndStep) == 2 {
+		if step, err = parseCronUint(rangeAndStep[1]); err != nil {
+			return 0, err
+		}
+		if step == 0 {
+			return 0, cerr.Errorf("step must be positive: %s", expr)
+		}
+	}
+
+	if start < bounds.min || end > bounds.max || start > end {
+		return 0, cerr.Errorf("out of range [%d, %d]: %s", bounds.min, bounds.max, expr)
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(value string, bounds cronBounds) (uint, error) {
+	if bounds.names != nil {
+		if i, found := bounds.names[strings.ToLower(value)]; found {
+			return i, nil
+		}
+	}
+
+	return parseCronUint(value)
+}
+
+func parseCronUint(value string) (uint, error) {
+	i, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return 0, cerr.Errorf("invalid number: %s", value)
+	}
+
+	return uint(i), nil
+}
+
+// Next 返回prev之后的下次触发时间,没有则返回零值
+func (s *CronSchedule) Next(prev time.Time) time.Time {
+	t := prev.In(s.location)
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+
+	var (
+		added     = false // 是否已将低位字段归零
+		yearLimit = t.Year() + cronYearLimit
+	)
+
+WRAP:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+
+	for 1<<uint(t.Month())&s.month == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.location)
+		}
+		t = t.AddDate(0, 1, 0)
+
+		if t.Month() == time.January {
+			goto WRAP
+		}
+	}
+
+	for !s.dayMatches(t) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+		}
+		t = t.AddDate(0, 0, 1)
+
+		// 夏令时切换可能导致零点不存在
+		if t.Hour() != 0 {
+			if t.Hour() > 12 {
+				t = t.Add(time.Duration(24-t.Hour()) * time.Hour)
+			} else {
+				t = t.Add(time.Duration(-t.Hour()) * time.Hour)
+			}
+		}
+
+		if t.Day() == 1 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Hour())&s.hour == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, s.location)
+		}
+		t = t.Add(time.Hour)
+
+		if t.Hour() == 0 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Minute())&s.minute == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+
+		if t.Minute() == 0 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Second())&s.second == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Second)
+		}
+		t = t.Add(time.Second)
+
+		if t.Second() == 0 {
+			goto WRAP
+		}
+	}
+
+	return t.In(prev.Location())
+}
+
+// dayMatches 日、周字段都有限制时满足其一即可
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := 1<<uint(t.Day())&s.dom > 0
+	dowMatch := 1<<uint(t.Weekday())&s.dow > 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
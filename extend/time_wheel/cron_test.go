@@ -0,0 +1,50 @@
+package cherryTimeWheel
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	prev := time.Date(2024, 1, 1, 12, 30, 15, 500, loc) // Monday
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/10 * * * * *", time.Date(2024, 1, 1, 12, 30, 20, 0, loc)},
+		{"0 0 5 * * *", time.Date(2024, 1, 2, 5, 0, 0, 0, loc)},
+		{"0 0 5 * * ?", time.Date(2024, 1, 2, 5, 0, 0, 0, loc)},
+		{"0 0 20 * * SAT,SUN", time.Date(2024, 1, 6, 20, 0, 0, 0, loc)},
+		{"0 0 0 * * 7", time.Date(2024, 1, 7, 0, 0, 0, 0, loc)},
+		{"0 0 0 1 FEB *", time.Date(2024, 2, 1, 0, 0, 0, 0, loc)},
+		{"0 0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, loc)},
+		{"0 9-18/3 * * MON-FRI", time.Date(2024, 1, 1, 15, 0, 0, 0, loc)},
+		{"@weekly", time.Date(2024, 1, 7, 0, 0, 0, 0, loc)},
+		{"CRON_TZ=UTC 0 0 0 * * *", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		s, err := ParseCron(test.spec, loc)
+		if err != nil {
+			t.Fatalf("spec = %s, err = %v", test.spec, err)
+		}
+
+		if got := s.Next(prev); !got.Equal(test.want) {
+			t.Fatalf("spec = %s, got = %v, want = %v", test.spec, got, test.want)
+		}
+	}
+}
+
+func TestCronParseError(t *testing.T) {
+	for _, spec := range []string{"", "* * *", "60 * * * * *", "* * * * 13 *", "*/0 * * * * *", "CRON_TZ=Bad/Zone * * * * *"} {
+		if _, err := ParseCron(spec, nil); err == nil {
+			t.Fatalf("spec = %s, expect error", spec)
+		}
+	}
+
+	if s, _ := ParseCron("0 0 0 30 2 *", nil); !s.Next(time.Now()).IsZero() {
+		t.Fatal("expect zero time")
+	}
+}
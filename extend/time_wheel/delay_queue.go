@@ -5,6 +5,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	ctime "github.com/cherry-game/cherry/extend/time"
 )
 
 // The start of PriorityQueue implementation.
@@ -119,6 +121,27 @@ func (dq *DelayQueue) Offer(elem interface{}, expiration int64) {
 	}
 }
 
+// wakeup makes the sleeping Poll() recalculate the delay with the current clock.
+func (dq *DelayQueue) wakeup() {
+	if atomic.CompareAndSwapInt32(&dq.sleeping, 1, 0) {
+		dq.wakeupC <- struct{}{}
+	}
+}
+
+// shift removes and returns an element whose expiration is not after now.
+// It returns nil if there is no expired element.
+func (dq *DelayQueue) shift(now int64) interface{} {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	value, _ := dq.pq.PeekAndShift(now)
+	if value == nil {
+		return nil
+	}
+
+	return value.Value
+}
+
 // Poll starts an infinite loop, in which it continually waits for an element
 // to expire and then send the expired element to the channel C.
 func (dq *DelayQueue) Poll(exitC chan struct{}, nowF func() int64) {
@@ -153,7 +176,7 @@ func (dq *DelayQueue) Poll(exitC chan struct{}, nowF func() int64) {
 				case <-dq.wakeupC:
 					// A new item with an "earlier" expiration than the current "earliest" one is added.
 					continue
-				case <-time.After(time.Duration(delta) * time.Millisecond):
+				case <-ctime.GetClock().After(time.Duration(delta) * time.Millisecond):
 					// The current "earliest" item expires.
 
 					// Reset the sleeping state since there's no need to receive from wakeupC.
@@ -6,6 +6,7 @@ import (
 	"time"
 	"unsafe"
 
+	ctime "github.com/cherry-game/cherry/extend/time"
 	cutils "github.com/cherry-game/cherry/extend/utils"
 	clog "github.com/cherry-game/cherry/logger"
 )
@@ -21,6 +22,7 @@ type TimeWheel struct {
 	overflowWheel unsafe.Pointer   // type: *TimeWheel The higher-level overflow wheel.
 	exitC         chan struct{}    // exit chan
 	waitGroup     waitGroupWrapper // wait group
+	cancelHooks   []func()         // unregister the clock hooks
 }
 
 // NewTimeWheel creates an instance of TimeWheel with the given tick and wheelSize.
@@ -31,7 +33,7 @@ func NewTimeWheel(tick time.Duration, wheelSize int64) *TimeWheel {
 		return nil
 	}
 
-	startMs := TimeToMS(time.Now().UTC())
+	startMs := TimeToMS(ctime.GetClock().Now().UTC())
 
 	return newTimingWheel(
 		tickMs,
@@ -138,10 +140,15 @@ func (tw *TimeWheel) advanceClock(expiration int64) {
 func (tw *TimeWheel) Start() {
 	tw.waitGroup.Wrap(func() {
 		tw.queue.Poll(tw.exitC, func() int64 {
-			return TimeToMS(time.Now().UTC())
+			return TimeToMS(ctime.GetClock().Now().UTC())
 		})
 	})
 
+	tw.cancelHooks = append(tw.cancelHooks,
+		ctime.OnAdvance(tw.advance),
+		ctime.OnSetClock(tw.resync),
+	)
+
 	tw.waitGroup.Wrap(func() {
 		for {
 			select {
@@ -156,12 +163,63 @@ func (tw *TimeWheel) Start() {
 	})
 }
 
+// advance flushes all expired buckets in the caller's goroutine.
+// It is called when the manual clock advances, so that expired timers fire synchronously.
+func (tw *TimeWheel) advance(now time.Time) {
+	select {
+	case <-tw.exitC:
+		return
+	default:
+	}
+
+	nowMs := TimeToMS(now.UTC())
+	for {
+		elem := tw.queue.shift(nowMs)
+		if elem == nil {
+			break
+		}
+
+		b := elem.(*bucket)
+		tw.advanceClock(b.Expiration())
+		b.Flush(tw.addOrRun)
+	}
+
+	tw.advanceClock(nowMs)
+}
+
+// resync sets the current time of the wheel to the new clock's time.
+// The new clock may be behind the wheel (e.g. the system clock is restored after a manual clock test),
+// otherwise every later timer would be treated as expired and fire immediately.
+func (tw *TimeWheel) resync(now time.Time) {
+	select {
+	case <-tw.exitC:
+		return
+	default:
+	}
+
+	tw.setCurrentTime(TimeToMS(now.UTC()))
+	tw.queue.wakeup()
+}
+
+func (tw *TimeWheel) setCurrentTime(ms int64) {
+	atomic.StoreInt64(&tw.currentTime, truncate(ms, tw.tick))
+
+	overflowWheel := atomic.LoadPointer(&tw.overflowWheel)
+	if overflowWheel != nil {
+		(*TimeWheel)(overflowWheel).setCurrentTime(ms)
+	}
+}
+
 // Stop stops the current timing wheel.
 //
 // If there is any timer's task being running in its own goroutine, Stop does
 // not wait for the task to complete before returning. If the caller needs to
 // know whether the task is completed, it must coordinate with the task explicitly.
 func (tw *TimeWheel) Stop() {
+	for _, cancel := range tw.cancelHooks {
+		cancel()
+	}
+
 	close(tw.exitC)
 	tw.waitGroup.Wait()
 }
@@ -171,7 +229,7 @@ func (tw *TimeWheel) Stop() {
 func (tw *TimeWheel) AfterFunc(id uint64, d time.Duration, f func(), async ...bool) *Timer {
 	t := &Timer{
 		id:         id,
-		expiration: TimeToMS(time.Now().UTC().Add(d)),
+		expiration: TimeToMS(ctime.GetClock().Now().UTC().Add(d)),
 		task:       f,
 		isAsync:    getAsyncValue(async...),
 	}
@@ -210,7 +268,7 @@ func (tw *TimeWheel) BuildEveryFunc(d time.Duration, f func(), async ...bool) *T
 // be executed, and f will be called at the next execution time if the time
 // is non-zero.
 func (tw *TimeWheel) ScheduleFunc(id uint64, s Scheduler, f func(), async ...bool) *Timer {
-	expiration := s.Next(time.Now())
+	expiration := s.Next(ctime.GetClock().Now())
 	if expiration.IsZero() {
 		// No time is scheduled, return nil.
 		return nil
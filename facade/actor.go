@@ -15,8 +15,10 @@ type (
 		PostEvent(data IEventData)
 		Call(source, target, funcName string, arg interface{}) int32
 		CallWait(source, target, funcName string, arg interface{}, reply interface{}) int32
+		CallPriority(source, target, funcName string, arg interface{}) int32
 		SetLocalInvoke(invoke InvokeFunc)
 		SetRemoteInvoke(invoke InvokeFunc)
+		Use(middlewares ...InvokeMiddleware)
 		SetCallTimeout(d time.Duration)
 		SetArrivalTimeout(t int64)
 		SetExecutionTimeout(t int64)
@@ -24,12 +26,16 @@ type (
 
 	InvokeFunc func(app IApplication, fi *creflect.FuncInfo, m *Message)
 
+	// InvokeMiddleware 包装InvokeFunc的中间件,可在调用next前后执行自定义逻辑
+	InvokeMiddleware func(next InvokeFunc) InvokeFunc
+
 	IActor interface {
 		App() IApplication
 		ActorID() string
 		Path() *ActorPath
 		Call(targetPath, funcName string, arg interface{}) int32
 		CallWait(targetPath, funcName string, arg interface{}, reply interface{}) int32
+		CallPriority(targetPath, funcName string, arg interface{}) int32
 		PostRemote(m *Message)
 		PostLocal(m *Message)
 		LastAt() int64
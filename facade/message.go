@@ -8,6 +8,7 @@ import (
 	cstring "github.com/cherry-game/cherry/extend/string"
 	ctime "github.com/cherry-game/cherry/extend/time"
 	cproto "github.com/cherry-game/cherry/net/proto"
+	"google.golang.org/protobuf/proto"
 )
 
 type (
@@ -24,6 +25,10 @@ type (
 		ClusterReply IRespond         // 返回消息的接口
 		IsCluster    bool             // 是否为集群消息
 		ChanResult   chan interface{} //
+		Priority     bool             // 是否为控制消息(优先处理)
+		CallChain    []string         // CallWait调用链中正在等待回复的actor path
+		replied      bool             // 是否已回复调用方
+		aborted      bool             // 是否已被中间件中断
 	}
 
 	IRespond interface {
@@ -82,6 +87,52 @@ func (p *Message) IsReply() bool {
 	return p.ClusterReply != nil
 }
 
+// Respond 回复等待的调用方(ChanResult或ClusterReply),重复调用只回复第一次
+func (p *Message) Respond(rsp *cproto.Response) error {
+	if p.replied {
+		return nil
+	}
+	p.replied = true
+
+	if p.ChanResult != nil {
+		select {
+		case p.ChanResult <- rsp:
+		default:
+		}
+	}
+
+	if p.ClusterReply != nil {
+		data, err := proto.Marshal(rsp)
+		if err != nil {
+			return err
+		}
+		return p.ClusterReply.Respond(data)
+	}
+
+	return nil
+}
+
+// Reply 回复调用方返回码
+func (p *Message) Reply(code int32) error {
+	return p.Respond(&cproto.Response{Code: code})
+}
+
+// Replied 是否已回复调用方
+func (p *Message) Replied() bool {
+	return p.replied
+}
+
+// Abort 中间件中断调用并回复调用方返回码,之后的中间件及函数不再执行
+func (p *Message) Abort(code int32) error {
+	p.aborted = true
+	return p.Reply(code)
+}
+
+// IsAborted 是否已被中间件中断
+func (p *Message) IsAborted() bool {
+	return p.aborted
+}
+
 func (p *ActorPath) IsChild() bool {
 	return p.ChildID != ""
 }
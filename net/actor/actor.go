@@ -2,13 +2,15 @@ package cherryActor
 
 import (
 	"strings"
+	"sync/atomic"
+	"time"
 
 	ccode "github.com/cherry-game/cherry/code"
+	creflect "github.com/cherry-game/cherry/extend/reflect"
 	ctime "github.com/cherry-game/cherry/extend/time"
 	cutils "github.com/cherry-game/cherry/extend/utils"
 	cfacade "github.com/cherry-game/cherry/facade"
 	clog "github.com/cherry-game/cherry/logger"
-	cproto "github.com/cherry-game/cherry/net/proto"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -41,7 +43,7 @@ type (
 	Actor struct {
 		system           *System               // actor system
 		path             *cfacade.ActorPath    // actor path
-		state            State                 // actor state
+		state            int32                 // actor state (atomic, use State()/setState())
 		close            chan struct{}         // close flag
 		handler          cfacade.IActorHandler // actor handler
 		localMail        *mailbox              // local message mailbox
@@ -53,6 +55,22 @@ type (
 		lastAt           int64                 // last process time (count of seconds)
 		arrivalElapsed   int64                 // arrival elapsed for message
 		executionElapsed int64                 // execution elapsed for message
+		restartTimes     []int64               // restart time list (count of milliseconds)
+		invokeChain      invokeChain           // invoke middleware
+		journalSeq       int64                 // last persisted journal seq
+		stash            actorStash            // stashed messages
+		priority         queue                 // priority lane for control messages
+		stopped          chan struct{}         // closed after the actor is stopped
+		invoking         atomic.Value          // the function name being invoked
+		behaviors        []behavior            // behavior stack
+		pooled           bool                  // scheduled on the worker pool (PoolMode)
+		scheduled        int32                 // in the run queue or running on a worker
+		started          bool                  // OnInit has been executed on a worker
+		restartPending   bool                  // restart requested by supervisor, executed in the actor loop
+		restartAt        time.Time             // restart is delayed until this time (backoff)
+		restartTimer     int32                 // PoolMode actor is waiting for the restart backoff
+		factory          ActorFactory          // rebuild the handler on restart (nil: reuse the handler)
+		discard          int32                 // stopped by supervisor or forced to exit, queued messages become dead letters
 	}
 )
 
@@ -68,8 +86,41 @@ func (p *Actor) run() {
 }
 
 func (p *Actor) loop() bool {
-	if p.state == StopState {
-		if p.localMail.Count() < 1 &&
+	// 控制消息优先处理
+	select {
+	case <-p.close:
+		p.setState(StopState)
+	default:
+	}
+
+	// 监督者要求的重启在loop中执行,OnInit反复panic时不会递归
+	if p.restartPending {
+		if p.State() == StopState {
+			// 重启前actor已停止,不再重启,未处理的消息转为死信
+			p.restartPending = false
+			p.stopOnFailure()
+		} else if wait := p.restartWait(); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-p.close:
+				p.setState(StopState)
+			}
+			return false
+		} else {
+			p.restartPending = false
+			p.restart()
+			return false
+		}
+	}
+
+	if p.State() == StopState {
+		if p.isDiscard() {
+			p.discardMessages()
+			return true
+		}
+
+		if p.priority.Count() < 1 &&
+			p.localMail.Count() < 1 &&
 			p.remoteMail.Count() < 1 &&
 			p.event.Count() < 1 &&
 			len(p.callback) < 1 {
@@ -77,7 +128,22 @@ func (p *Actor) loop() bool {
 		}
 	}
 
+	if p.priority.Count() > 0 {
+		p.processPriority()
+		return false
+	}
+
+	// 已恢复的暂存消息优先处理
+	if len(p.stash.unstashed) > 0 && p.State() == WorkerState {
+		p.processUnstashed()
+		return false
+	}
+
 	select {
+	case <-p.priority.C:
+		{
+			p.processPriority()
+		}
 	case <-p.localMail.C:
 		{
 			p.processLocal()
@@ -100,7 +166,7 @@ func (p *Actor) loop() bool {
 		}
 	case <-p.close:
 		{
-			p.state = StopState
+			p.setState(StopState)
 		}
 	}
 
@@ -113,29 +179,36 @@ func (p *Actor) processLocal() {
 		return
 	}
 
-	p.lastAt = ctime.Now().ToSecond()
+	p.setLastAt()
+	p.receiveLocal(m)
+}
+
+func (p *Actor) receiveLocal(m *cfacade.Message) {
+	p.stash.setCurrent(p.localMail, m)
+	defer p.stash.setCurrent(nil, nil)
 
 	next, invoke := p.handler.OnLocalReceived(m)
 	if invoke {
-		p.invokeFunc(p.localMail, p.App(), p.system.localInvokeFunc, m)
+		p.invokeFunc(p.localMail, p.App(), p.localInvoke(), m)
 	}
 
-	if !next {
+	if !next || p.stash.stashed {
 		return
 	}
 
 	if m.TargetPath().IsChild() {
 		if p.path.IsChild() {
-			p.invokeFunc(p.localMail, p.App(), p.system.localInvokeFunc, m)
+			p.invokeFunc(p.localMail, p.App(), p.localInvoke(), m)
 		} else {
 			if childActor, foundChild := p.findChildActor(m); foundChild {
-				childActor.PostLocal(m)
+				childActor.pushLocal(m)
 			} else {
 				clog.Warnf("Child actor not found. path = %s", m.Target)
+				p.system.deadLetter(m, LocalName, ChildNotFoundReason)
 			}
 		}
 	} else {
-		p.invokeFunc(p.localMail, p.App(), p.system.localInvokeFunc, m)
+		p.invokeFunc(p.localMail, p.App(), p.localInvoke(), m)
 	}
 }
 
@@ -145,32 +218,50 @@ func (p *Actor) processRemote() {
 		return
 	}
 
-	p.lastAt = ctime.Now().ToSecond()
+	p.setLastAt()
+	p.receiveRemote(m)
+}
+
+func (p *Actor) receiveRemote(m *cfacade.Message) {
+	p.stash.setCurrent(p.remoteMail, m)
+	defer p.stash.setCurrent(nil, nil)
 
 	next, invoke := p.handler.OnRemoteReceived(m)
 	if invoke {
-		p.invokeFunc(p.remoteMail, p.App(), p.system.remoteInvokeFunc, m)
+		p.invokeFunc(p.remoteMail, p.App(), p.remoteInvoke(), m)
 	}
 
-	if !next {
+	if !next || p.stash.stashed {
 		return
 	}
 
 	if m.TargetPath().IsChild() {
 		if p.path.IsChild() {
-			p.invokeFunc(p.remoteMail, p.App(), p.system.remoteInvokeFunc, m)
+			p.invokeFunc(p.remoteMail, p.App(), p.remoteInvoke(), m)
 		} else {
 			if childActor, foundChild := p.findChildActor(m); foundChild {
-				childActor.PostRemote(m)
+				childActor.pushRemote(m)
 			} else {
-				retResponse(m.ClusterReply, &cproto.Response{
-					Code: ccode.ActorCallFail,
-				})
 				clog.Warnf("Child actor not found. path = %s", m.Target)
+				p.system.deadLetter(m, RemoteName, ChildNotFoundReason)
 			}
 		}
 	} else {
-		p.invokeFunc(p.remoteMail, p.App(), p.system.remoteInvokeFunc, m)
+		p.invokeFunc(p.remoteMail, p.App(), p.remoteInvoke(), m)
+	}
+}
+
+// processUnstashed 处理一条已恢复的暂存消息
+func (p *Actor) processUnstashed() {
+	item, found := p.stash.popUnstashed()
+	if !found {
+		return
+	}
+
+	if item.mb == p.localMail {
+		p.receiveLocal(item.message)
+	} else {
+		p.receiveRemote(item.message)
 	}
 }
 
@@ -180,7 +271,7 @@ func (p *Actor) processEvent() {
 		return
 	}
 
-	p.lastAt = ctime.Now().ToSecond()
+	p.setLastAt()
 	p.event.invokeFunc(eventData)
 }
 
@@ -193,6 +284,7 @@ func (p *Actor) invokeFunc(mb *mailbox, app cfacade.IApplication, fn cfacade.Inv
 			m.Target,
 			m.FuncName,
 		)
+		p.system.deadLetter(m, mb.name, FuncNotFoundReason)
 		return
 	}
 
@@ -232,12 +324,57 @@ func (p *Actor) invokeFunc(mb *mailbox, app cfacade.IApplication, fn cfacade.Inv
 				funcInfo.InArgs,
 				rev,
 			)
+
+			// 中间件未回复调用方时,回复执行错误
+			if !m.Replied() {
+				if err := m.Reply(ccode.RPCRemoteExecuteError); err != nil {
+					clog.Warn(err)
+				}
+			}
+			p.onFailure(rev)
 		}
 	}()
 
+	p.invoking.Store(m.FuncName)
+	defer p.invoking.Store("")
+
+	// 在中间件链之前反序列化参数,中间件可读取已解码的Args
+	if !p.decodeArgs(mb, app, funcInfo, m) {
+		return
+	}
+
 	fn(app, funcInfo, m)
 }
 
+func (p *Actor) decodeArgs(mb *mailbox, app cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade.Message) bool {
+	if app == nil {
+		return true
+	}
+
+	var err error
+	if mb == p.localMail {
+		err = EncodeLocalArgs(app, fi, m)
+	} else {
+		err = EncodeRemoteArgs(app, fi, m)
+	}
+
+	if err != nil {
+		clog.Warnf("[%s] Decode args error. [source = %s, target = %s->%s, err = %v]",
+			mb.name,
+			m.Source,
+			m.Target,
+			m.FuncName,
+			err,
+		)
+		if err = m.Reply(ccode.ActorUnmarshalError); err != nil {
+			clog.Warn(err)
+		}
+		return false
+	}
+
+	return true
+}
+
 func (p *Actor) findChildActor(m *cfacade.Message) (*Actor, bool) {
 	// 如果当前actor为子actor,则终止本次消息处理
 	if p.path.IsChild() {
@@ -264,18 +401,180 @@ func (p *Actor) findChildActor(m *cfacade.Message) (*Actor, bool) {
 }
 
 func (p *Actor) onInit() {
-	p.state = WorkerState
+	if p.State() == InitState {
+		p.restoreSnapshot()
+		p.replayJournal()
+	}
+
+	p.setState(WorkerState)
 	cutils.Try(p.handler.OnInit, func(err string) {
 		clog.Error(err)
+		p.onFailure(err)
+	})
+}
+
+// supervisorStrategy 子actor使用父actor声明的监督策略,顶层actor使用System的监督策略
+func (p *Actor) supervisorStrategy() *SupervisorStrategy {
+	if p.path.IsChild() {
+		if parent, found := p.system.GetActor(p.path.ActorID); found {
+			if supervisor, ok := parent.handler.(ISupervisor); ok {
+				if strategy := supervisor.SupervisorStrategy(); strategy != nil {
+					return strategy
+				}
+			}
+		}
+	}
+
+	return p.system.supervisorStrategy
+}
+
+// onFailure actor处理消息或初始化时发生panic,根据监督策略进行处理
+func (p *Actor) onFailure(reason interface{}) {
+	if p.State() == StopState {
+		return
+	}
+
+	strategy := p.supervisorStrategy()
+	directive := strategy.decide(p, reason)
+
+	clog.Warnf("[onFailure] actor failure. [path = %s, directive = %s, reason = %v]",
+		p.path,
+		directive,
+		reason,
+	)
+
+	switch directive {
+	case RestartDirective:
+		{
+			if ok, backoff := strategy.requestRestart(p); ok {
+				p.restartPending = true
+				p.restartAt = time.Now().Add(backoff)
+			} else {
+				clog.Warnf("[onFailure] Restart too many times, actor will stop. [path = %s, maxRestarts = %d, within = %s]",
+					p.path,
+					strategy.MaxRestarts,
+					strategy.Within,
+				)
+				p.stopOnFailure()
+			}
+		}
+	case StopDirective:
+		{
+			p.stopOnFailure()
+		}
+	case EscalateDirective:
+		{
+			p.escalate(reason)
+		}
+	}
+}
+
+// escalate 将异常上报给父actor处理,父actor在自己的goroutine中执行监督逻辑
+func (p *Actor) escalate(reason interface{}) {
+	if p.path.IsParent() {
+		p.stopOnFailure()
+		return
+	}
+
+	parent, found := p.system.GetActor(p.path.ActorID)
+	if !found {
+		p.stopOnFailure()
+		return
+	}
+
+	parent.pushCallback(func() {
+		parent.onFailure(reason)
 	})
 }
 
+// restartWait 重启前剩余的退避时间
+func (p *Actor) restartWait() time.Duration {
+	if !p.restartPending || p.State() == StopState {
+		return 0
+	}
+
+	return time.Until(p.restartAt)
+}
+
+// restart 重启actor,保留邮箱中未处理的消息.actor由factory创建时重新创建handler,不保留崩溃前的状态
+func (p *Actor) restart() {
+	cutils.Try(p.handler.OnStop, func(errString string) {
+		clog.Error(errString)
+	})
+
+	if p.factory != nil {
+		if handler := p.factory(p.ActorID()); handler != nil {
+			p.handler = handler
+			if actorLoad, ok := handler.(IActorLoader); ok {
+				actorLoad.load(p)
+			}
+		}
+	}
+
+	if p.path.IsParent() {
+		p.child.Each(func(childActor cfacade.IActor) {
+			childActor.Exit()
+		})
+	}
+
+	p.timer.reset()
+	p.event.reset()
+	p.resetBehavior()
+	p.localMail.reset()
+	p.remoteMail.reset()
+	p.invokeChain.reset()
+	p.registerInnerFunc()
+	p.UnstashAll()
+
+	p.onInit()
+}
+
+// stopOnFailure 监督者停止actor,handler可能处于异常状态,队列中未处理的消息不再执行
+func (p *Actor) stopOnFailure() {
+	p.setState(StopState)
+	atomic.StoreInt32(&p.discard, 1)
+}
+
+func (p *Actor) isDiscard() bool {
+	return atomic.LoadInt32(&p.discard) == 1
+}
+
+// discardMessages 将队列中未处理的消息转为死信
+func (p *Actor) discardMessages() {
+	for v := p.priority.Pop(); v != nil; v = p.priority.Pop() {
+		if item, ok := v.(*envelope); ok {
+			p.system.deadLetter(item.message, item.mb.name, ActorStoppedReason)
+		}
+	}
+
+	for m := p.localMail.pop(); m != nil; m = p.localMail.pop() {
+		p.system.deadLetter(m, LocalName, ActorStoppedReason)
+	}
+
+	for m := p.remoteMail.pop(); m != nil; m = p.remoteMail.pop() {
+		p.system.deadLetter(m, RemoteName, ActorStoppedReason)
+	}
+
+	for data := p.event.pop(); data != nil; data = p.event.pop() {
+		clog.Warnf("[discardMessages] Actor stopped, drop event. [path = %s, name = %s]", p.path, data.Name())
+	}
+}
+
+func (p *Actor) registerInnerFunc() {
+	// register update timer func
+	p.remoteMail.Register(updateTimerFuncName, p.timer._updateTimer_)
+}
+
 func (p *Actor) onStop() {
 	cutils.Try(func() {
 		close(p.close)
 
 		if p.path.IsParent() {
-			p.system.removeActor(p.ActorID())
+			p.system.removeActor(p)
+			if _, found := p.system.GetActor(p.ActorID()); !found {
+				p.system.location.unregister(p.ActorID())
+			}
+			p.system.routers.onStop(p)
 			p.child.onStop()
 		} else {
 			if parent, found := p.system.GetActor(p.path.ActorID); found {
@@ -284,6 +583,11 @@ func (p *Actor) onStop() {
 		}
 
 		p.handler.OnStop()
+		p.saveSnapshot()
+		for _, item := range p.stash.drain() {
+			p.system.deadLetter(item.message, item.mb.name, ActorStoppedReason)
+		}
+		p.priority.Destroy()
 		p.timer.onStop()
 		p.event.onStop()
 		p.localMail.onStop()
@@ -292,11 +596,19 @@ func (p *Actor) onStop() {
 		clog.Error(errString)
 	})
 
+	close(p.stopped)
+	if p.path.IsParent() {
+		p.system.passivation.onStopped(p)
+	}
 	p.system.wg.Done()
 }
 
 func (p *Actor) State() State {
-	return p.state
+	return State(atomic.LoadInt32(&p.state))
+}
+
+func (p *Actor) setState(state State) {
+	atomic.StoreInt32(&p.state, int32(state))
 }
 
 func (p *Actor) App() cfacade.IApplication {
@@ -324,16 +636,45 @@ func (p *Actor) Call(targetPath, funcName string, arg interface{}) int32 {
 }
 
 func (p *Actor) CallWait(targetPath, funcName string, arg interface{}, reply interface{}) int32 {
-	return p.system.CallWait(p.path.String(), targetPath, funcName, arg, reply)
+	return p.system.callWait(p.path.String(), targetPath, funcName, arg, reply, p.callChain())
+}
+
+func (p *Actor) CallByID(actorID, funcName string, arg interface{}) int32 {
+	return p.system.CallByID(p.path.String(), actorID, funcName, arg)
+}
+
+func (p *Actor) CallWaitByID(actorID, funcName string, arg interface{}, reply interface{}) int32 {
+	return p.system.location.callWait(p.path.String(), actorID, funcName, arg, reply, p.callChain())
+}
+
+// CallAsync 发送远程消息(异步回复)
+// 不阻塞当前actor,回复结果将在当前actor的goroutine中通过callback返回
+// reply为接收回复数据的指针,与CallWait的reply参数相同
+// 超过callTimeout未收到回复时,callback的code为ActorCallTimeout
+func (p *Actor) CallAsync(targetPath, funcName string, arg interface{}, reply interface{}, callback AsyncCallback) {
+	code := ccode.ActorCallFail
+
+	p.Go(func() {
+		code = p.system.CallWait(p.path.String(), targetPath, funcName, arg, reply)
+	}, func() {
+		if callback != nil {
+			callback(reply, code)
+		}
+	})
 }
 
 // LastAt second
 func (p *Actor) LastAt() int64 {
-	return p.lastAt
+	return atomic.LoadInt64(&p.lastAt)
+}
+
+func (p *Actor) setLastAt() {
+	atomic.StoreInt64(&p.lastAt, ctime.Now().ToSecond())
 }
 
 func (p *Actor) Exit() {
 	p.close <- struct{}{}
+	p.signal()
 
 	if clog.PrintLevel(zapcore.DebugLevel) {
 		clog.Debugf("[Exit] actor exit! path = %s", p.path)
@@ -365,11 +706,11 @@ func (p *Actor) Timer() ITimer {
 }
 
 func (p *Actor) PostRemote(m *cfacade.Message) {
-	p.remoteMail.Push(m)
+	p.pushRemote(m)
 }
 
 func (p *Actor) PostLocal(m *cfacade.Message) {
-	p.localMail.Push(m)
+	p.pushLocal(m)
 }
 
 func (p *Actor) PostEvent(data cfacade.IEventData) {
@@ -379,7 +720,7 @@ func (p *Actor) PostEvent(data cfacade.IEventData) {
 func (p *Actor) Go(f func(), cb func()) {
 	go func() {
 		defer func() {
-			p.callback <- cb
+			p.pushCallback(cb)
 			if r := recover(); r != nil {
 				clog.Error("%v", r)
 			}
@@ -401,7 +742,7 @@ func newActor(actorID, childID string, handler cfacade.IActorHandler, c *System)
 			ActorID: actorID,
 			ChildID: childID,
 		},
-		state:   InitState,
+		state:   int32(InitState),
 		system:  c,
 		close:   make(chan struct{}, 1),
 		handler: handler,
@@ -409,12 +750,23 @@ func newActor(actorID, childID string, handler cfacade.IActorHandler, c *System)
 	}
 
 	localMailbox := newMailbox(LocalName)
+	localMailbox.onDrop = func(m *cfacade.Message) {
+		c.deadLetter(m, LocalName, MailboxFullReason)
+	}
+	localMailbox.serializer = c.serializer
+	localMailbox.SetCapacity(c.mailboxOverflow.capacity, c.mailboxOverflow.policy, c.mailboxOverflow.blockTimeout)
 	thisActor.localMail = &localMailbox
 
 	remoteMailbox := newMailbox(RemoteName)
+	remoteMailbox.onDrop = func(m *cfacade.Message) {
+		c.deadLetter(m, RemoteName, MailboxFullReason)
+	}
+	remoteMailbox.serializer = c.serializer
+	remoteMailbox.SetCapacity(c.mailboxOverflow.capacity, c.mailboxOverflow.policy, c.mailboxOverflow.blockTimeout)
 	thisActor.remoteMail = &remoteMailbox
 
 	event := newEvent(&thisActor)
+	event.SetCapacity(c.mailboxOverflow.capacity, c.mailboxOverflow.policy, c.mailboxOverflow.blockTimeout)
 	thisActor.event = &event
 
 	child := newChild(&thisActor)
@@ -424,9 +776,19 @@ func newActor(actorID, childID string, handler cfacade.IActorHandler, c *System)
 	thisActor.timer = &timer
 
 	thisActor.callback = make(chan func(), 1000)
+	thisActor.priority = newQueue()
+	thisActor.stopped = make(chan struct{})
+
+	if c.schedulerModeOf(handler) == PoolMode {
+		thisActor.pooled = true
+		thisActor.callback = make(chan func(), pooledCallbackBuffer)
+		thisActor.priority.onPush = thisActor.schedule
+		thisActor.localMail.onPush = thisActor.schedule
+		thisActor.remoteMail.onPush = thisActor.schedule
+		thisActor.event.onPush = thisActor.schedule
+	}
 
-	// register update timer func
-	thisActor.remoteMail.Register(updateTimerFuncName, thisActor.timer._updateTimer_)
+	thisActor.registerInnerFunc()
 
 	// spawn load!
 	actorLoad, ok := handler.(IActorLoader)
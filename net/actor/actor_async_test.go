@@ -0,0 +1,137 @@
+package cherryActor
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	ccode "github.com/cherry-game/cherry/code"
+	creflect "github.com/cherry-game/cherry/extend/reflect"
+	cfacade "github.com/cherry-game/cherry/facade"
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+type (
+	testAsyncCaller struct {
+		Base
+		goroutineID uint64 // OnInit所在的goroutine
+	}
+
+	testAsyncTarget struct {
+		Base
+		block chan struct{}
+	}
+
+	testAsyncReply struct {
+		goroutineID uint64 // callback所在的goroutine
+		code        int32
+	}
+)
+
+func (p *testAsyncCaller) OnInit() {
+	p.goroutineID = goroutineID()
+}
+
+func (p *testAsyncTarget) OnInit() {
+	p.Remote().Register("ping", func() {})
+	p.Remote().Register("block", func() {
+		<-p.block
+	})
+}
+
+// replyRemoteInvoke 不经过序列化直接调用remote函数,并回复调用方
+func replyRemoteInvoke(app cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade.Message) {
+	directRemoteInvoke(app, fi, m)
+
+	if m.ChanResult != nil {
+		m.ChanResult <- &cproto.Response{Code: ccode.OK}
+	}
+}
+
+// goroutineID 从调用栈中解析当前goroutine id
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	id, _ := strconv.ParseUint(string(buf[:bytes.IndexByte(buf, ' ')]), 10, 64)
+	return id
+}
+
+func callAsync(t *testing.T, caller *testAsyncCaller, funcName string) testAsyncReply {
+	result := make(chan testAsyncReply, 1)
+
+	caller.CallAsync(".target", funcName, nil, nil, func(_ interface{}, code int32) {
+		result <- testAsyncReply{
+			goroutineID: goroutineID(),
+			code:        code,
+		}
+	})
+
+	select {
+	case reply := <-result:
+		return reply
+	case <-time.After(time.Second):
+		t.Fatal("callback not invoked")
+	}
+
+	return testAsyncReply{}
+}
+
+func TestCallAsyncActorNotFound(t *testing.T) {
+	system := NewSystem()
+
+	handler := &testActor{}
+	system.CreateActor("caller", handler)
+
+	result := make(chan int32, 1)
+	handler.CallAsync(".notFound", "login", nil, nil, func(_ interface{}, code int32) {
+		result <- code
+	})
+
+	select {
+	case code := <-result:
+		if code != ccode.ActorCallFail {
+			t.Fatalf("code = %d", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("callback not invoked")
+	}
+}
+
+func TestCallAsyncReply(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(replyRemoteInvoke)
+
+	caller := &testAsyncCaller{}
+	callerActor, _ := system.CreateActor("caller", caller)
+	waitInit(t, callerActor.(*Actor))
+	system.CreateActor("target", &testAsyncTarget{})
+
+	reply := callAsync(t, caller, "ping")
+	if reply.code != ccode.OK {
+		t.Fatalf("code = %d", reply.code)
+	}
+
+	if reply.goroutineID != caller.goroutineID {
+		t.Fatalf("callback goroutine = %d, caller goroutine = %d", reply.goroutineID, caller.goroutineID)
+	}
+}
+
+func TestCallAsyncTimeout(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+	system.SetCallTimeout(50 * time.Millisecond)
+
+	caller := &testAsyncCaller{}
+	system.CreateActor("caller", caller)
+
+	target := &testAsyncTarget{block: make(chan struct{})}
+	system.CreateActor("target", target)
+	defer close(target.block)
+
+	if reply := callAsync(t, caller, "block"); reply.code != ccode.ActorCallTimeout {
+		t.Fatalf("code = %d", reply.code)
+	}
+}
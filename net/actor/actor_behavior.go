@@ -0,0 +1,53 @@
+package cherryActor
+
+import (
+	creflect "github.com/cherry-game/cherry/extend/reflect"
+)
+
+type (
+	// behavior actor当前生效的local/remote函数集合
+	behavior struct {
+		local  map[string]*creflect.FuncInfo
+		remote map[string]*creflect.FuncInfo
+	}
+)
+
+// Become 切换actor的行为,setup中通过local/remote注册新行为的函数,旧的函数集合压入栈中
+// 可通过Unbecome()恢复之前的行为.只能在actor goroutine中调用
+func (p *Actor) Become(setup func(local, remote IMailBox)) {
+	p.behaviors = append(p.behaviors, behavior{
+		local:  p.localMail.funcMap,
+		remote: p.remoteMail.funcMap,
+	})
+
+	p.localMail.funcMap = make(map[string]*creflect.FuncInfo)
+	p.remoteMail.funcMap = make(map[string]*creflect.FuncInfo)
+	p.registerInnerFunc()
+
+	if setup != nil {
+		setup(p.localMail, p.remoteMail)
+	}
+}
+
+// Unbecome 恢复到上一次Become()之前的行为
+func (p *Actor) Unbecome() {
+	size := len(p.behaviors)
+	if size < 1 {
+		return
+	}
+
+	last := p.behaviors[size-1]
+	p.behaviors = p.behaviors[:size-1]
+
+	p.localMail.funcMap = last.local
+	p.remoteMail.funcMap = last.remote
+}
+
+// resetBehavior 恢复到初始行为
+func (p *Actor) resetBehavior() {
+	if len(p.behaviors) > 0 {
+		p.localMail.funcMap = p.behaviors[0].local
+		p.remoteMail.funcMap = p.behaviors[0].remote
+		p.behaviors = nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	cherryCode "github.com/cherry-game/cherry/code"
 	cfacade "github.com/cherry-game/cherry/facade"
+	clog "github.com/cherry-game/cherry/logger"
 )
 
 type actorChild struct {
@@ -20,18 +21,41 @@ func newChild(thisActor *Actor) actorChild {
 	}
 }
 
+// onStop 停止所有子actor,system停止时等待子actor停止
 func (p *actorChild) onStop() {
+	var children []*Actor
+
 	p.childActors.Range(func(key, value any) bool {
 		if childActor, ok := value.(*Actor); ok {
-			childActor.Exit()
+			childActor.exit()
+			children = append(children, childActor)
 		}
 		return true
 	})
 
+	// 只在system停止时等待子actor(截止时间与system相同),钝化等单个actor的停止不阻塞
+	if system := p.thisActor.system; system.isStopping() {
+		for _, childActor := range system.waitActors(children, system.stopAt) {
+			clog.Warnf("[onStop] Child actor stop timeout. [%s]", childActor.report())
+			childActor.forceStop()
+		}
+	}
+
 	//p.childActors = nil
 	p.thisActor = nil
 }
 
+// hasChildren 是否存在子actor
+func (p *actorChild) hasChildren() bool {
+	found := false
+	p.childActors.Range(func(_, _ any) bool {
+		found = true
+		return false
+	})
+
+	return found
+}
+
 func (p *actorChild) Create(childID string, handler cfacade.IActorHandler) (cfacade.IActor, error) {
 	if p.thisActor.path.IsChild() {
 		return nil, ErrForbiddenCreateChildActor
@@ -51,7 +75,7 @@ func (p *actorChild) Create(childID string, handler cfacade.IActorHandler) (cfac
 	}
 
 	p.childActors.Store(childID, childActor)
-	go childActor.run()
+	childActor.start()
 
 	return childActor, nil
 }
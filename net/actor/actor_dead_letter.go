@@ -0,0 +1,121 @@
+package cherryActor
+
+import (
+	"sync"
+	"sync/atomic"
+
+	ccode "github.com/cherry-game/cherry/code"
+	cutils "github.com/cherry-game/cherry/extend/utils"
+	cfacade "github.com/cherry-game/cherry/facade"
+	clog "github.com/cherry-game/cherry/logger"
+)
+
+var (
+	ActorNotFoundReason DeadLetterReason = 0 // 目标actor不存在
+	ChildNotFoundReason DeadLetterReason = 1 // 目标子actor不存在
+	FuncNotFoundReason  DeadLetterReason = 2 // 目标函数未注册
+	ActorStoppedReason  DeadLetterReason = 3 // 目标actor已停止
+	MailboxFullReason   DeadLetterReason = 4 // 目标邮箱已满,消息被丢弃
+)
+
+const (
+	deadLetterReasonCount = 5
+)
+
+type (
+	DeadLetterReason int32
+
+	// DeadLetter 无法投递的消息
+	DeadLetter struct {
+		Message  *cfacade.Message // 原始消息
+		Reason   DeadLetterReason // 无法投递的原因
+		MailName string           // 邮箱名(local or remote)
+		Time     int64            // 产生时间(毫秒)
+	}
+
+	// DeadLetterFunc 死信订阅函数,在产生死信的goroutine中同步执行,不要执行耗时操作
+	DeadLetterFunc func(letter *DeadLetter)
+
+	deadLetters struct {
+		sync.RWMutex
+		listeners []DeadLetterFunc
+		counters  [deadLetterReasonCount]int64
+	}
+)
+
+func newDeadLetters() *deadLetters {
+	return &deadLetters{}
+}
+
+func (p *deadLetters) subscribe(fn DeadLetterFunc) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.listeners = append(p.listeners, fn)
+}
+
+func (p *deadLetters) count(reason DeadLetterReason) int64 {
+	if !reason.valid() {
+		return 0
+	}
+
+	return atomic.LoadInt64(&p.counters[reason])
+}
+
+func (p *deadLetters) publish(letter *DeadLetter) {
+	if letter.Reason.valid() {
+		atomic.AddInt64(&p.counters[letter.Reason], 1)
+	}
+
+	// 通知等待回复的调用方
+	replyDropped(letter.Message, letter.Reason.Code())
+
+	p.RLock()
+	listeners := p.listeners
+	p.RUnlock()
+
+	for _, listener := range listeners {
+		cutils.Try(func() {
+			listener(letter)
+		}, func(errString string) {
+			clog.Warnf("[DeadLetter] listener error. [reason = %s, err = %s]", letter.Reason, errString)
+		})
+	}
+}
+
+func (r DeadLetterReason) valid() bool {
+	return r >= 0 && r < deadLetterReasonCount
+}
+
+// Code 死信原因对应的返回码
+func (r DeadLetterReason) Code() int32 {
+	switch r {
+	case ChildNotFoundReason:
+		return ccode.ActorChildIDNotFound
+	case FuncNotFoundReason:
+		return ccode.ActorFuncNameError
+	case MailboxFullReason:
+		return ccode.ActorMailboxFull
+	case ActorStoppedReason:
+		return ccode.ActorStopping
+	}
+
+	return ccode.ActorCallFail
+}
+
+func (r DeadLetterReason) String() string {
+	switch r {
+	case ActorNotFoundReason:
+		return "actor_not_found"
+	case ChildNotFoundReason:
+		return "child_not_found"
+	case FuncNotFoundReason:
+		return "func_not_found"
+	case ActorStoppedReason:
+		return "actor_stopped"
+	case MailboxFullReason:
+		return "mailbox_full"
+	}
+
+	return "unknown"
+}
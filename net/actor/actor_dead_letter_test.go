@@ -0,0 +1,34 @@
+package cherryActor
+
+import (
+	"testing"
+)
+
+func TestDeadLetterActorNotFound(t *testing.T) {
+	system := NewSystem()
+
+	var letters []*DeadLetter
+	system.OnDeadLetter(func(letter *DeadLetter) {
+		letters = append(letters, letter)
+	})
+
+	m := newTestMessage("login")
+	m.Target = ".notFound"
+	m.ChanResult = make(chan interface{}, 1)
+
+	if system.PostRemote(m) {
+		t.Fatal("post to not found actor")
+	}
+
+	if len(letters) != 1 || letters[0].Reason != ActorNotFoundReason {
+		t.Fatalf("letters = %+v", letters)
+	}
+
+	if system.DeadLetterCount(ActorNotFoundReason) != 1 {
+		t.Fatal("dead letter count error")
+	}
+
+	if len(m.ChanResult) != 1 {
+		t.Fatal("dead letter not replied")
+	}
+}
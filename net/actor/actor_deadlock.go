@@ -0,0 +1,41 @@
+package cherryActor
+
+import (
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+// callChain 当前actor发起CallWait时的调用链: 正在处理的消息的调用链 + 当前actor
+func (p *Actor) callChain() []string {
+	var chain []string
+	if current := p.stash.current; current != nil {
+		chain = make([]string, 0, len(current.message.CallChain)+1)
+		chain = append(chain, current.message.CallChain...)
+	}
+
+	return append(chain, p.fullPath(p.path))
+}
+
+// fullPath 补全nodeID后的actor path
+func (p *Actor) fullPath(path *cfacade.ActorPath) string {
+	return p.system.fullPath(path)
+}
+
+func (p *System) fullPath(path *cfacade.ActorPath) string {
+	nodeID := path.NodeID
+	if nodeID == "" {
+		nodeID = p.NodeID()
+	}
+
+	return cfacade.NewChildPath(nodeID, path.ActorID, path.ChildID)
+}
+
+// isDeadlock 目标actor是否正在调用链中等待回复
+func isDeadlock(chain []string, target string) bool {
+	for _, path := range chain {
+		if path == target {
+			return true
+		}
+	}
+
+	return false
+}
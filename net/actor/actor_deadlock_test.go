@@ -0,0 +1,52 @@
+package cherryActor
+
+import (
+	"testing"
+	"time"
+
+	ccode "github.com/cherry-game/cherry/code"
+	creflect "github.com/cherry-game/cherry/extend/reflect"
+	cfacade "github.com/cherry-game/cherry/facade"
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+type testCycleActor struct {
+	Base
+	peer   string
+	result chan int32
+}
+
+func (p *testCycleActor) OnInit() {
+	p.Remote().Register("start", func() {
+		p.CallWait(p.peer, "ping", nil, nil)
+	})
+	p.Remote().Register("ping", func() {
+		p.result <- p.CallWait(p.peer, "pong", nil, nil)
+	})
+	p.Remote().Register("pong", func() {})
+}
+
+func TestCallWaitDeadlock(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(func(app cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade.Message) {
+		directRemoteInvoke(app, fi, m)
+		if m.ChanResult != nil {
+			m.ChanResult <- &cproto.Response{}
+		}
+	})
+
+	result := make(chan int32, 1)
+	system.CreateActor("a", &testCycleActor{peer: ".b", result: result})
+	system.CreateActor("b", &testCycleActor{peer: ".a", result: result})
+
+	system.Call(".caller", ".a", "start", nil)
+
+	select {
+	case code := <-result:
+		if code != ccode.ActorCallDeadlock {
+			t.Fatalf("code = %d", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("deadlock not detected")
+	}
+}
@@ -1,21 +1,27 @@
 package cherryActor
 
 import (
+	creflect "github.com/cherry-game/cherry/extend/reflect"
 	cfacade "github.com/cherry-game/cherry/facade"
 	clog "github.com/cherry-game/cherry/logger"
+	cproto "github.com/cherry-game/cherry/net/proto"
 )
 
 type actorEvent struct {
-	thisActor *Actor                  // parent
-	queue                             // queue
-	funcMap   map[string][]IEventFunc // register event func map
+	thisActor  *Actor                          // parent
+	queue                                      // queue
+	overflow                                   // overflow policy
+	funcMap    map[string][]*creflect.FuncInfo // register event func map
+	topicFuncs map[string][]*creflect.FuncInfo // subscribed topic func map
 }
 
 func newEvent(thisActor *Actor) actorEvent {
 	return actorEvent{
-		thisActor: thisActor,
-		queue:     newQueue(),
-		funcMap:   make(map[string][]IEventFunc),
+		thisActor:  thisActor,
+		queue:      newQueue(),
+		overflow:   newOverflow(),
+		funcMap:    make(map[string][]*creflect.FuncInfo),
+		topicFuncs: make(map[string][]*creflect.FuncInfo),
 	}
 }
 
@@ -23,9 +29,12 @@ func newEvent(thisActor *Actor) actorEvent {
 // name 事件名
 // fn 接收事件处理的函数
 func (p *actorEvent) Register(name string, fn IEventFunc) {
-	funcList := p.funcMap[name]
-	funcList = append(funcList, fn)
-	p.funcMap[name] = funcList
+	funcInfo, ok := eventFuncInfo(name, fn)
+	if !ok {
+		return
+	}
+
+	p.funcMap[name] = append(p.funcMap[name], funcInfo)
 }
 
 func (p *actorEvent) Registers(names []string, fn IEventFunc) {
@@ -42,7 +51,7 @@ func (p *actorEvent) Unregister(name string) {
 
 func (p *actorEvent) Push(data cfacade.IEventData) {
 	if _, found := p.funcMap[data.Name()]; found {
-		p.queue.Push(data)
+		p.offerEvent(data, data.Name())
 	}
 
 	if p.thisActor.Path().IsChild() {
@@ -56,8 +65,35 @@ func (p *actorEvent) Push(data cfacade.IEventData) {
 	})
 }
 
+// offerEvent 按溢出策略将事件入队,name为事件名或主题名
+func (p *actorEvent) offerEvent(data cfacade.IEventData, name string) {
+	_, ok := p.offer(&p.queue, data, func(v interface{}) {
+		if oldest, ok := v.(cfacade.IEventData); ok {
+			clog.Warnf("[%s] Event queue is full, drop oldest event. [name = %s]",
+				p.thisActor.Path(),
+				oldest.Name(),
+			)
+		}
+	})
+
+	if !ok {
+		clog.Warnf("[%s] Event queue is full, drop event. [name = %s, count = %d]",
+			p.thisActor.Path(),
+			name,
+			p.Count(),
+		)
+	}
+}
+
 func (p *actorEvent) Pop() cfacade.IEventData {
-	v := p.queue.Pop()
+	data := p.pop()
+	p.notifySpace()
+
+	return data
+}
+
+func (p *actorEvent) pop() cfacade.IEventData {
+	v := p.popQueue(&p.queue)
 	if v == nil {
 		return nil
 	}
@@ -72,7 +108,18 @@ func (p *actorEvent) Pop() cfacade.IEventData {
 }
 
 func (p *actorEvent) invokeFunc(data cfacade.IEventData) {
-	funcList, found := p.funcMap[data.Name()]
+	var (
+		funcList []*creflect.FuncInfo
+		found    bool
+	)
+
+	if event, ok := data.(*topicEvent); ok {
+		funcList, found = p.topicFuncs[event.topic]
+		data = event.IEventData
+	} else {
+		funcList, found = p.funcMap[data.Name()]
+	}
+
 	if !found {
 		clog.Warnf("[%s] Event not found. [data = %+v]",
 			p.thisActor.Path(),
@@ -88,16 +135,67 @@ func (p *actorEvent) invokeFunc(data cfacade.IEventData) {
 				data,
 				rev,
 			)
+			p.thisActor.onFailure(rev)
 		}
 	}()
 
-	for _, eventFunc := range funcList {
-		eventFunc(data)
+	// 事件函数与local/remote函数一样经过中间件链
+	invoke := p.thisActor.eventInvoke()
+	for _, funcInfo := range funcList {
+		m := &cfacade.Message{
+			Source:   p.thisActor.PathString(),
+			Target:   p.thisActor.PathString(),
+			FuncName: data.Name(),
+			Session:  &cproto.Session{},
+			Args:     data,
+		}
+
+		invoke(p.thisActor.App(), funcInfo, m)
+	}
+}
+
+// InvokeEventFunc 执行事件函数,为event调用链的最后一环
+func InvokeEventFunc(_ cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade.Message) {
+	if m.IsAborted() {
+		return
+	}
+
+	data, ok := m.Args.(cfacade.IEventData)
+	if !ok {
+		clog.Warnf("[InvokeEventFunc] Convert to IEventData fail. [target = %s, args = %+v]", m.Target, m.Args)
+		return
+	}
+
+	eventFunc, ok := fi.Value.Interface().(IEventFunc)
+	if !ok {
+		clog.Warnf("[InvokeEventFunc] Convert to IEventFunc fail. [target = %s -> %s]", m.Target, m.FuncName)
+		return
+	}
+
+	eventFunc(data)
+}
+
+// eventFuncInfo 获取事件函数信息,传给中间件
+func eventFuncInfo(name string, fn IEventFunc) (*creflect.FuncInfo, bool) {
+	funcInfo, err := creflect.GetFuncInfo(fn)
+	if err != nil {
+		clog.Warnf("[eventFuncInfo] Event func error. [name = %s, err = %v]", name, err)
+		return nil, false
 	}
+
+	return &funcInfo, true
+}
+
+// reset 清空已注册的事件函数
+func (p *actorEvent) reset() {
+	p.funcMap = make(map[string][]*creflect.FuncInfo)
+	p.unsubscribeAll()
 }
 
 func (p *actorEvent) onStop() {
+	p.unsubscribeAll()
 	p.funcMap = nil
+	p.topicFuncs = nil
 	p.queue.Destroy()
-	p.thisActor = nil
+	// 保留thisActor,其他goroutine并发推送事件(pushTopic)时仍会读取
 }
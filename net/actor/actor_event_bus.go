@@ -0,0 +1,147 @@
+package cherryActor
+
+import (
+	"reflect"
+	"sync"
+
+	cfacade "github.com/cherry-game/cherry/facade"
+	clog "github.com/cherry-game/cherry/logger"
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+const (
+	EventBusActorID = "eventBus" // 集群事件总线actor id
+	onEventFuncName = "onEvent"
+)
+
+type (
+	// eventBus 集群事件总线,将事件发布到其他节点的所有actor
+	//
+	// 事件通过app.Serializer()序列化,接收节点根据IEventData.Name()查找已注册的事件类型进行解码
+	eventBus struct {
+		sync.RWMutex
+		system *System
+		types  map[string]reflect.Type // key:event name, value:event type
+	}
+
+	// eventBusActor 接收其他节点发布的事件,并投递给本节点的所有actor
+	eventBusActor struct {
+		Base
+		bus *eventBus
+	}
+)
+
+func newEventBus(system *System) *eventBus {
+	return &eventBus{
+		system: system,
+		types:  make(map[string]reflect.Type),
+	}
+}
+
+func (p *eventBus) register(data cfacade.IEventData) {
+	typ := reflect.TypeOf(data)
+	if typ.Kind() != reflect.Ptr {
+		clog.Warnf("[eventBus] Event type must be a pointer. [name = %s, type = %v]", data.Name(), typ)
+		return
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	p.types[data.Name()] = typ.Elem()
+}
+
+func (p *eventBus) enabled() bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return len(p.types) > 0
+}
+
+func (p *eventBus) onAfterInit() {
+	if !p.enabled() {
+		return
+	}
+
+	if _, err := p.system.CreateActor(EventBusActorID, &eventBusActor{bus: p}); err != nil {
+		clog.Warnf("[eventBus] Create actor fail. [err = %v]", err)
+	}
+}
+
+// decode 根据事件名称解码事件数据
+func (p *eventBus) decode(serializer cfacade.ISerializer, event *cproto.ClusterEvent) (cfacade.IEventData, bool) {
+	p.RLock()
+	typ, found := p.types[event.Name]
+	p.RUnlock()
+
+	if !found {
+		clog.Warnf("[eventBus] Event type not registered. [name = %s]", event.Name)
+		return nil, false
+	}
+
+	value := reflect.New(typ).Interface()
+	if err := serializer.Unmarshal(event.Data, value); err != nil {
+		clog.Warnf("[eventBus] Unmarshal event fail. [name = %s, err = %v]", event.Name, err)
+		return nil, false
+	}
+
+	data, ok := value.(cfacade.IEventData)
+	return data, ok
+}
+
+// publish 发布事件到其他节点,nodeTypes为空则发布到所有节点
+func (p *eventBus) publish(data cfacade.IEventData, nodeTypes ...string) {
+	app := p.system.app
+	if app == nil || app.Discovery() == nil {
+		return
+	}
+
+	bytes, err := app.Serializer().Marshal(data)
+	if err != nil {
+		clog.Warnf("[eventBus] Marshal event fail. [name = %s, err = %v]", data.Name(), err)
+		return
+	}
+
+	event := &cproto.ClusterEvent{
+		Name: data.Name(),
+		Data: bytes,
+	}
+
+	source := cfacade.NewPath(app.NodeID(), EventBusActorID)
+
+	for nodeID, member := range app.Discovery().Map() {
+		if nodeID == app.NodeID() || !matchNodeType(member.GetNodeType(), nodeTypes) {
+			continue
+		}
+
+		p.system.Call(source, cfacade.NewPath(nodeID, EventBusActorID), onEventFuncName, event)
+	}
+}
+
+func matchNodeType(nodeType string, nodeTypes []string) bool {
+	if len(nodeTypes) < 1 {
+		return true
+	}
+
+	for _, t := range nodeTypes {
+		if t == nodeType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *eventBusActor) AliasID() string {
+	return EventBusActorID
+}
+
+func (p *eventBusActor) OnInit() {
+	p.Remote().Register(onEventFuncName, p.onEvent)
+}
+
+func (p *eventBusActor) onEvent(event *cproto.ClusterEvent) {
+	if data, ok := p.bus.decode(p.App().Serializer(), event); ok {
+		p.system.PostEvent(data)
+	}
+}
@@ -0,0 +1,50 @@
+package cherryActor
+
+import (
+	"testing"
+
+	cproto "github.com/cherry-game/cherry/net/proto"
+	cserializer "github.com/cherry-game/cherry/net/serializer"
+)
+
+type testMaintenanceEvent struct {
+	Minutes int `json:"minutes"`
+}
+
+func (*testMaintenanceEvent) Name() string {
+	return "maintenance"
+}
+
+func (*testMaintenanceEvent) UniqueID() int64 {
+	return 0
+}
+
+func TestEventBusDecode(t *testing.T) {
+	serializer := cserializer.NewJSON()
+	bus := newEventBus(nil)
+	bus.register(&testMaintenanceEvent{})
+
+	bytes, _ := serializer.Marshal(&testMaintenanceEvent{Minutes: 30})
+	data, ok := bus.decode(serializer, &cproto.ClusterEvent{Name: "maintenance", Data: bytes})
+	if !ok {
+		t.Fatal("decode fail")
+	}
+
+	if event, ok := data.(*testMaintenanceEvent); !ok || event.Minutes != 30 {
+		t.Fatalf("data = %+v", data)
+	}
+
+	if _, ok = bus.decode(serializer, &cproto.ClusterEvent{Name: "unknown"}); ok {
+		t.Fatal("unknown event must fail")
+	}
+}
+
+func TestMatchNodeType(t *testing.T) {
+	if !matchNodeType("game", nil) || !matchNodeType("game", []string{"center", "game"}) {
+		t.Fatal("match fail")
+	}
+
+	if matchNodeType("gate", []string{"game"}) {
+		t.Fatal("match gate")
+	}
+}
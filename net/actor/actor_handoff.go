@@ -0,0 +1,77 @@
+package cherryActor
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	clog "github.com/cherry-game/cherry/logger"
+)
+
+const (
+	handoffTimeout = 10 * time.Second // 等待actor执行OnHandoff的最长时间
+)
+
+type (
+	// IShardHandler 分片actor,所在分片迁移到其他节点时收到通知
+	IShardHandler interface {
+		// ShardKey 返回分片的节点类型及实体key(在非actor goroutine中调用,返回值应不可变)
+		ShardKey() (nodeType string, key string)
+		// OnHandoff 分片迁移到toNodeID节点,在actor goroutine中执行.可将状态迁移到新节点后调用Exit().
+		// 所有OnHandoff执行完成(或超时)后才切换hash环,新节点收到消息时状态已迁移完成
+		OnHandoff(toNodeID string)
+	}
+)
+
+// Handoff 分片变化时,通知分片已迁移到其他节点的actor(包括子actor),等待OnHandoff执行完成后返回
+// locate 根据实体key获取新的节点id
+func (p *System) Handoff(nodeType string, locate func(key string) (string, bool)) {
+	var wg sync.WaitGroup
+	p.eachActor(func(thisActor *Actor) {
+		thisActor.handoff(nodeType, locate, &wg)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(handoffTimeout):
+		clog.Warnf("[handoff] Wait actor handoff timeout. [nodeType = %s]", nodeType)
+	}
+}
+
+func (p *Actor) handoff(nodeType string, locate func(key string) (string, bool), wg *sync.WaitGroup) {
+	if p.State() != WorkerState {
+		return
+	}
+
+	shardHandler, ok := p.handler.(IShardHandler)
+	if !ok {
+		return
+	}
+
+	shardNodeType, shardKey := shardHandler.ShardKey()
+	if shardNodeType != nodeType {
+		return
+	}
+
+	toNodeID, found := locate(shardKey)
+	if !found || toNodeID == p.system.NodeID() {
+		return
+	}
+
+	if clog.PrintLevel(zapcore.DebugLevel) {
+		clog.Debugf("[handoff] shard moved. [path = %s, key = %s, toNodeID = %s]", p.path, shardKey, toNodeID)
+	}
+
+	wg.Add(1)
+	p.pushCallback(func() {
+		defer wg.Done()
+		shardHandler.OnHandoff(toNodeID)
+	})
+}
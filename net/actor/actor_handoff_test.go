@@ -0,0 +1,41 @@
+package cherryActor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testShardActor struct {
+	Base
+	handoff int32
+}
+
+func (p *testShardActor) ShardKey() (string, string) {
+	return "game", "1001"
+}
+
+func (p *testShardActor) OnHandoff(toNodeID string) {
+	time.Sleep(50 * time.Millisecond)
+	if toNodeID == "game-2" {
+		atomic.StoreInt32(&p.handoff, 1)
+	}
+}
+
+func TestHandoffWait(t *testing.T) {
+	system := NewSystem()
+	defer system.Stop()
+
+	handler := &testShardActor{}
+	thisActor, _ := system.CreateActor("player_1001", handler)
+	waitInit(t, thisActor.(*Actor))
+
+	// Handoff返回时OnHandoff已执行完成,之后才切换hash环
+	system.Handoff("game", func(key string) (string, bool) {
+		return "game-2", key == "1001"
+	})
+
+	if atomic.LoadInt32(&handler.handoff) != 1 {
+		t.Fatal("handoff not finished")
+	}
+}
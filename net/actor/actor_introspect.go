@@ -0,0 +1,184 @@
+package cherryActor
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	ccode "github.com/cherry-game/cherry/code"
+	creflect "github.com/cherry-game/cherry/extend/reflect"
+	cfacade "github.com/cherry-game/cherry/facade"
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+const (
+	IntrospectActorID = "introspect" // 查询actor运行状态的actor id
+	statsFuncName     = "stats"
+	statTimeout       = 200 * time.Millisecond // 等待actor goroutine返回函数及定时器信息的时间
+)
+
+type (
+	// introspectActor 通过remote函数查询本节点的actor运行状态
+	introspectActor struct {
+		Base
+	}
+
+	// actorDetail 需要在actor goroutine中读取的信息
+	actorDetail struct {
+		localFuncs  []string
+		remoteFuncs []string
+		timerCount  int32
+		stashCount  int32
+	}
+)
+
+// NewIntrospectActor 创建查询actor运行状态的actor,其他节点通过System.RemoteStats()查询
+func NewIntrospectActor() *introspectActor {
+	return &introspectActor{}
+}
+
+func (p *introspectActor) AliasID() string {
+	return IntrospectActorID
+}
+
+func (p *introspectActor) OnInit() {
+	p.Remote().Register(statsFuncName, p.stats)
+}
+
+func (p *introspectActor) stats(req *cproto.ActorStatRequest) (*cproto.ActorStatList, int32) {
+	// 排除自身:当前goroutine正在执行stats,无法处理读取详情的callback,只能等待超时
+	return &cproto.ActorStatList{
+		List: p.system.stats(req.Prefix, req.Children, p.Actor),
+	}, ccode.OK
+}
+
+func (s State) String() string {
+	switch s {
+	case InitState:
+		return "init"
+	case WorkerState:
+		return "worker"
+	case FreeState:
+		return "free"
+	case StopState:
+		return "stop"
+	}
+
+	return "unknown"
+}
+
+func funcNames(funcMap map[string]*creflect.FuncInfo) []string {
+	names := make([]string, 0, len(funcMap))
+	for name := range funcMap {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// stat 读取actor的运行状态,函数及定时器信息通过callback在actor goroutine中读取
+func (p *Actor) stat() (*cproto.ActorStat, chan *actorDetail) {
+	invoking, _ := p.invoking.Load().(string)
+
+	stat := &cproto.ActorStat{
+		Path:          p.path.String(),
+		State:         p.State().String(),
+		LocalCount:    p.localMail.Count(),
+		RemoteCount:   p.remoteMail.Count(),
+		EventCount:    p.event.Count(),
+		PriorityCount: p.priority.Count(),
+		LastAt:        p.LastAt(),
+		Invoking:      invoking,
+	}
+
+	if p.path.IsParent() {
+		p.child.Each(func(_ cfacade.IActor) {
+			stat.ChildCount++
+		})
+	}
+
+	detailChan := make(chan *actorDetail, 1)
+	fn := func() {
+		detailChan <- &actorDetail{
+			localFuncs:  funcNames(p.localMail.funcMap),
+			remoteFuncs: funcNames(p.remoteMail.funcMap),
+			timerCount:  int32(len(p.timer.timerInfoMap)),
+			stashCount:  int32(len(p.stash.messages) + len(p.stash.unstashed)),
+		}
+	}
+
+	p.offerCallback(fn)
+
+	return stat, detailChan
+}
+
+// Stats 获取actorID匹配prefix的actor运行状态,withChildren为true时包含子actor
+// 阻塞(如正在执行耗时函数)的actor,Responsive为false且不包含函数及定时器信息
+func (p *System) Stats(prefix string, withChildren bool) []*cproto.ActorStat {
+	return p.stats(prefix, withChildren, nil)
+}
+
+// stats exclude 不需要查询的actor
+func (p *System) stats(prefix string, withChildren bool, exclude *Actor) []*cproto.ActorStat {
+	var actors []*Actor
+
+	p.actorMap.Range(func(key, value any) bool {
+		thisActor, ok := value.(*Actor)
+		if !ok || thisActor == exclude || !strings.HasPrefix(thisActor.ActorID(), prefix) {
+			return true
+		}
+
+		actors = append(actors, thisActor)
+		if withChildren {
+			thisActor.child.Each(func(iActor cfacade.IActor) {
+				if childActor, ok := iActor.(*Actor); ok {
+					actors = append(actors, childActor)
+				}
+			})
+		}
+		return true
+	})
+
+	var (
+		list        = make([]*cproto.ActorStat, len(actors))
+		detailChans = make([]chan *actorDetail, len(actors))
+		timeout     = time.After(statTimeout)
+	)
+
+	for i, thisActor := range actors {
+		list[i], detailChans[i] = thisActor.stat()
+	}
+
+	for i, detailChan := range detailChans {
+		select {
+		case detail := <-detailChan:
+			list[i].LocalFuncs = detail.localFuncs
+			list[i].RemoteFuncs = detail.remoteFuncs
+			list[i].TimerCount = detail.timerCount
+			list[i].StashCount = detail.stashCount
+			list[i].Responsive = true
+		case <-timeout:
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Path < list[j].Path
+	})
+
+	return list
+}
+
+// RemoteStats 查询指定节点的actor运行状态,目标节点需要创建NewIntrospectActor()
+func (p *System) RemoteStats(nodeID, prefix string, withChildren bool) ([]*cproto.ActorStat, int32) {
+	source := cfacade.NewPath(p.NodeID(), IntrospectActorID+"Client")
+	target := cfacade.NewPath(nodeID, IntrospectActorID)
+
+	rsp := &cproto.ActorStatList{}
+	code := p.CallWait(source, target, statsFuncName, &cproto.ActorStatRequest{
+		Prefix:   prefix,
+		Children: withChildren,
+	}, rsp)
+
+	return rsp.List, code
+}
@@ -0,0 +1,69 @@
+package cherryActor
+
+import (
+	"testing"
+	"time"
+
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+type testStatActor struct {
+	Base
+	blocked chan struct{}
+}
+
+func (p *testStatActor) OnInit() {
+	p.Remote().Register("block", func() {
+		<-p.blocked
+	})
+	p.Remote().Register("ping", func() {})
+}
+
+func TestStats(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+
+	handler := &testStatActor{blocked: make(chan struct{})}
+	system.CreateActor("stat1", handler)
+	system.CreateActor("stat2", &testStatActor{})
+	system.CreateActor("other", &testStatActor{})
+	time.Sleep(10 * time.Millisecond)
+
+	system.Call(".caller", ".stat1", "block", nil)
+	time.Sleep(10 * time.Millisecond)
+
+	list := system.Stats("stat", false)
+	if len(list) != 2 {
+		t.Fatalf("len = %d", len(list))
+	}
+
+	if list[0].Path != ".stat1" || list[0].Responsive || list[0].Invoking != "block" {
+		t.Fatalf("stat1 = %+v", list[0])
+	}
+
+	if list[1].Path != ".stat2" || !list[1].Responsive || len(list[1].RemoteFuncs) != 3 || list[1].State != "worker" {
+		t.Fatalf("stat2 = %+v", list[1])
+	}
+
+	close(handler.blocked)
+}
+
+func TestStatsExcludeIntrospect(t *testing.T) {
+	system := NewSystem()
+	defer system.Stop()
+
+	handler := NewIntrospectActor()
+	thisActor, _ := system.CreateActor(IntrospectActorID, handler)
+	system.CreateActor("stat1", &testStatActor{})
+	waitInit(t, thisActor.(*Actor))
+
+	begin := time.Now()
+	rsp, _ := handler.stats(&cproto.ActorStatRequest{})
+	if elapsed := time.Since(begin); elapsed >= statTimeout {
+		t.Fatalf("elapsed = %v", elapsed)
+	}
+
+	if len(rsp.List) != 1 || rsp.List[0].Path != ".stat1" {
+		t.Fatalf("list = %+v", rsp.List)
+	}
+}
@@ -0,0 +1,271 @@
+package cherryActor
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	cutils "github.com/cherry-game/cherry/extend/utils"
+	clog "github.com/cherry-game/cherry/logger"
+)
+
+const (
+	DefaultJournalDir  = "./journal" // 默认的事件日志目录
+	journalFileExt     = ".journal"
+	journalHeaderLen   = 16 // seq(8) + data length(4) + checksum(4)
+	journalSeqLen      = 8
+	journalLenLen      = 4
+	journalFileMode    = 0644
+	journalMaxEventLen = 64 * 1024 * 1024
+)
+
+type (
+	// journals 事件溯源actor的事件日志
+	//
+	// handler实现IEventSourced接口的actor,通过Persist()先将领域事件追加到journal再应用到状态,
+	// 创建时从最后一次快照的seq开始回放journal
+	journals struct {
+		sync.RWMutex
+		journal IJournal
+	}
+
+	// FileJournal 基于文件的事件日志,每个actor一个只追加的文件
+	//
+	// 记录格式: seq(8 bytes) + data length(4 bytes) + checksum(4 bytes) + data
+	// checksum为seq、data length及data的crc32
+	FileJournal struct {
+		sync.Mutex
+		dir string
+	}
+)
+
+func (p *journals) set(journal IJournal) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.journal = journal
+}
+
+func (p *journals) get() IJournal {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.journal
+}
+
+// Persist 将领域事件追加到journal,成功后调用handler的ApplyEvent()应用事件
+// 只能在actor goroutine中调用
+func (p *Actor) Persist(data []byte) error {
+	eventSourced, ok := p.handler.(IEventSourced)
+	if !ok {
+		return ErrNotEventSourced
+	}
+
+	journal := p.system.journals.get()
+	if journal == nil {
+		return ErrJournalNotSet
+	}
+
+	seq := p.journalSeq + 1
+	if err := journal.Append(p.snapshotKey(), seq, data); err != nil {
+		clog.Warnf("[journal] Append fail. [path = %s, seq = %d, err = %v]", p.path, seq, err)
+		return err
+	}
+
+	p.journalSeq = seq
+	eventSourced.ApplyEvent(data)
+
+	return nil
+}
+
+// JournalSeq 最后一个已持久化事件的序号
+func (p *Actor) JournalSeq() int64 {
+	return p.journalSeq
+}
+
+// replayJournal 从journalSeq之后开始回放事件
+func (p *Actor) replayJournal() {
+	eventSourced, ok := p.handler.(IEventSourced)
+	if !ok {
+		return
+	}
+
+	journal := p.system.journals.get()
+	if journal == nil {
+		return
+	}
+
+	err := journal.Replay(p.snapshotKey(), p.journalSeq, func(seq int64, data []byte) error {
+		cutils.Try(func() {
+			eventSourced.ApplyEvent(data)
+		}, func(errString string) {
+			clog.Warnf("[journal] Replay event fail. [path = %s, seq = %d, err = %s]", p.path, seq, errString)
+		})
+
+		p.journalSeq = seq
+		return nil
+	})
+
+	if err != nil {
+		clog.Warnf("[journal] Replay fail. [path = %s, seq = %d, err = %v]", p.path, p.journalSeq, err)
+	}
+}
+
+// encodeSeqSnapshot 事件溯源actor的快照需要记录对应的journal seq
+func encodeSeqSnapshot(seq int64, data []byte) []byte {
+	buf := make([]byte, journalSeqLen+len(data))
+	binary.BigEndian.PutUint64(buf, uint64(seq))
+	copy(buf[journalSeqLen:], data)
+	return buf
+}
+
+func decodeSeqSnapshot(buf []byte) (int64, []byte, bool) {
+	if len(buf) < journalSeqLen {
+		return 0, nil, false
+	}
+
+	return int64(binary.BigEndian.Uint64(buf)), buf[journalSeqLen:], true
+}
+
+// NewFileJournal 创建基于文件的事件日志
+func NewFileJournal(dir string) *FileJournal {
+	if dir == "" {
+		dir = DefaultJournalDir
+	}
+
+	return &FileJournal{
+		dir: dir,
+	}
+}
+
+func (p *FileJournal) fileName(actorID string) string {
+	return filepath.Join(p.dir, url.PathEscape(actorID)+journalFileExt)
+}
+
+func (p *FileJournal) Append(actorID string, seq int64, data []byte) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if err := os.MkdirAll(p.dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(p.fileName(actorID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, journalFileMode)
+	if err != nil {
+		return err
+	}
+
+	record := make([]byte, journalHeaderLen+len(data))
+	binary.BigEndian.PutUint64(record, uint64(seq))
+	binary.BigEndian.PutUint32(record[journalSeqLen:], uint32(len(data)))
+	copy(record[journalHeaderLen:], data)
+	binary.BigEndian.PutUint32(record[journalSeqLen+journalLenLen:], journalChecksum(record[:journalSeqLen+journalLenLen], data))
+
+	if _, err = file.Write(record); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func journalChecksum(header, data []byte) uint32 {
+	return crc32.Update(crc32.ChecksumIEEE(header), crc32.IEEETable, data)
+}
+
+// Replay 末尾不完整或校验失败的记录(写入中断)将被截断,之后追加的记录从最后一条完整记录后开始
+func (p *FileJournal) Replay(actorID string, fromSeq int64, fn func(seq int64, data []byte) error) error {
+	file, err := os.Open(p.fileName(actorID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	var (
+		reader = bufio.NewReader(file)
+		header = make([]byte, journalHeaderLen)
+		offset int64 // 最后一条完整记录的结束位置
+	)
+
+	for {
+		if _, err = io.ReadFull(reader, header); err != nil {
+			break
+		}
+
+		seq := int64(binary.BigEndian.Uint64(header))
+		dataLen := binary.BigEndian.Uint32(header[journalSeqLen:])
+		checksum := binary.BigEndian.Uint32(header[journalSeqLen+journalLenLen:])
+		if dataLen > journalMaxEventLen {
+			err = ErrJournalCorrupted
+			break
+		}
+
+		data := make([]byte, dataLen)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			break
+		}
+
+		recordLen := int64(journalHeaderLen) + int64(dataLen)
+		if journalChecksum(header[:journalSeqLen+journalLenLen], data) != checksum {
+			// 只有文件末尾的记录可能是写入中断,中间的记录校验失败则为文件损坏
+			if offset+recordLen == stat.Size() {
+				err = io.ErrUnexpectedEOF
+			} else {
+				err = ErrJournalCorrupted
+			}
+			break
+		}
+
+		offset += recordLen
+
+		if seq <= fromSeq {
+			continue
+		}
+
+		if err = fn(seq, data); err != nil {
+			return err
+		}
+	}
+
+	if err == io.ErrUnexpectedEOF {
+		clog.Warnf("[FileJournal] Incomplete record is truncated. [actorID = %s, offset = %d]", actorID, offset)
+		return p.truncate(actorID, offset)
+	}
+
+	if err == io.EOF {
+		return nil
+	}
+
+	if err == ErrJournalCorrupted {
+		clog.Warnf("[FileJournal] Record is corrupted. [actorID = %s, offset = %d]", actorID, offset)
+	}
+
+	return err
+}
+
+// truncate 截断末尾不完整的记录
+func (p *FileJournal) truncate(actorID string, offset int64) error {
+	p.Lock()
+	defer p.Unlock()
+
+	return os.Truncate(p.fileName(actorID), offset)
+}
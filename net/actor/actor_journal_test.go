@@ -0,0 +1,172 @@
+package cherryActor
+
+import (
+	"os"
+	"testing"
+)
+
+type testSourcedActor struct {
+	Base
+	balance  int
+	inited   chan int
+	persists []byte
+}
+
+func (p *testSourcedActor) OnInit() {
+	for _, amount := range p.persists {
+		if err := p.Persist([]byte{amount}); err != nil {
+			panic(err)
+		}
+	}
+	p.inited <- p.balance
+}
+
+func (p *testSourcedActor) ApplyEvent(data []byte) {
+	p.balance += int(data[0])
+}
+
+func (p *testSourcedActor) Snapshot() []byte {
+	return []byte{byte(p.balance)}
+}
+
+func (p *testSourcedActor) Restore(data []byte) {
+	p.balance = int(data[0])
+}
+
+func TestJournalReplay(t *testing.T) {
+	journal := NewFileJournal(t.TempDir())
+
+	for i := byte(1); i <= 3; i++ {
+		if err := journal.Append("wallet", int64(i), []byte{i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	system := NewSystem()
+	system.SetJournal(journal)
+
+	handler := &testSourcedActor{inited: make(chan int, 1), persists: []byte{4}}
+	system.CreateActor("wallet", handler)
+
+	if balance := <-handler.inited; balance != 10 {
+		t.Fatalf("balance = %d", balance)
+	}
+
+	var seqList []int64
+	journal.Replay("wallet", 2, func(seq int64, _ []byte) error {
+		seqList = append(seqList, seq)
+		return nil
+	})
+
+	if len(seqList) != 2 || seqList[1] != 4 {
+		t.Fatalf("seqList = %v", seqList)
+	}
+}
+
+func TestJournalReplayFromSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	journal := NewFileJournal(dir)
+	store := NewFileStore(dir)
+
+	for i := byte(1); i <= 3; i++ {
+		journal.Append("wallet", int64(i), []byte{i})
+	}
+	// snapshot at seq 2, balance = 1 + 2
+	store.Save("wallet", encodeSeqSnapshot(2, []byte{3}))
+
+	system := NewSystem()
+	system.SetJournal(journal)
+	system.SetSnapshotStore(store, 0)
+
+	handler := &testSourcedActor{inited: make(chan int, 1)}
+	system.CreateActor("wallet", handler)
+
+	if balance := <-handler.inited; balance != 6 {
+		t.Fatalf("balance = %d", balance)
+	}
+}
+
+func replaySeqList(t *testing.T, journal *FileJournal, actorID string) []int64 {
+	var seqList []int64
+	err := journal.Replay(actorID, 0, func(seq int64, _ []byte) error {
+		seqList = append(seqList, seq)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return seqList
+}
+
+func TestJournalTruncateTornTail(t *testing.T) {
+	journal := NewFileJournal(t.TempDir())
+	for i := byte(1); i <= 2; i++ {
+		journal.Append("wallet", int64(i), []byte{i})
+	}
+
+	// 模拟写入中断:末尾只写入了部分记录
+	file, _ := os.OpenFile(journal.fileName("wallet"), os.O_WRONLY|os.O_APPEND, journalFileMode)
+	file.Write([]byte{0, 0, 0, 0, 0, 0, 0, 3, 0})
+	file.Close()
+
+	if seqList := replaySeqList(t, journal, "wallet"); len(seqList) != 2 {
+		t.Fatalf("seqList = %v", seqList)
+	}
+
+	// 截断后追加的记录可以被回放
+	journal.Append("wallet", 3, []byte{3})
+	if seqList := replaySeqList(t, journal, "wallet"); len(seqList) != 3 || seqList[2] != 3 {
+		t.Fatalf("seqList = %v", seqList)
+	}
+}
+
+func TestJournalChecksum(t *testing.T) {
+	journal := NewFileJournal(t.TempDir())
+	for i := byte(1); i <= 3; i++ {
+		journal.Append("wallet", int64(i), []byte{i})
+	}
+
+	// 损坏最后一条记录的数据
+	name := journal.fileName("wallet")
+	buf, _ := os.ReadFile(name)
+	buf[len(buf)-1] ^= 0xff
+	os.WriteFile(name, buf, journalFileMode)
+
+	if seqList := replaySeqList(t, journal, "wallet"); len(seqList) != 2 {
+		t.Fatalf("seqList = %v", seqList)
+	}
+
+	if info, _ := os.Stat(name); info.Size() != 2*(journalHeaderLen+1) {
+		t.Fatalf("size = %d", info.Size())
+	}
+}
+
+func TestJournalCorruptedMiddle(t *testing.T) {
+	journal := NewFileJournal(t.TempDir())
+	for i := byte(1); i <= 3; i++ {
+		journal.Append("wallet", int64(i), []byte{i})
+	}
+
+	// 损坏中间记录的数据,之后的记录不能被截断
+	name := journal.fileName("wallet")
+	buf, _ := os.ReadFile(name)
+	buf[2*journalHeaderLen+1-1] ^= 0xff
+	os.WriteFile(name, buf, journalFileMode)
+
+	var seqList []int64
+	err := journal.Replay("wallet", 0, func(seq int64, _ []byte) error {
+		seqList = append(seqList, seq)
+		return nil
+	})
+	if err != ErrJournalCorrupted {
+		t.Fatalf("err = %v", err)
+	}
+
+	if len(seqList) != 1 {
+		t.Fatalf("seqList = %v", seqList)
+	}
+
+	if info, _ := os.Stat(name); info.Size() != int64(len(buf)) {
+		t.Fatalf("size = %d", info.Size())
+	}
+}
@@ -0,0 +1,203 @@
+package cherryActor
+
+import (
+	"strings"
+	"sync"
+
+	ccode "github.com/cherry-game/cherry/code"
+	cfacade "github.com/cherry-game/cherry/facade"
+	clog "github.com/cherry-game/cherry/logger"
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+const (
+	locationActorID       = "actorLocation" // 位置服务的虚拟actor id,由System直接处理,用于接收其他节点的通知
+	actorNotFoundFuncName = "actorNotFound" // 通知来源节点:目标actor不在本节点
+)
+
+type (
+	// location actor位置服务,通过actorID解析actor所在的节点
+	location struct {
+		system   *System
+		registry IActorRegistry // actor注册中心
+		prefixes []string       // 需要注册的actorID前缀(为空则注册所有顶层actor)
+		cache    sync.Map       // key:actorID, value:nodeID
+	}
+)
+
+func newLocation(system *System) *location {
+	return &location{
+		system: system,
+	}
+}
+
+func (p *location) enabled() bool {
+	return p.registry != nil
+}
+
+func (p *location) match(actorID string) bool {
+	if len(p.prefixes) < 1 {
+		return true
+	}
+
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(actorID, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *location) register(actorID string) {
+	if p.enabled() && p.match(actorID) {
+		p.registry.Register(actorID, p.system.NodeID())
+	}
+}
+
+func (p *location) unregister(actorID string) {
+	if p.enabled() && p.match(actorID) {
+		p.registry.Unregister(actorID, p.system.NodeID())
+	}
+}
+
+// resolve 获取actor所在的节点id
+func (p *location) resolve(actorID string) (string, bool) {
+	if _, found := p.system.GetActor(actorID); found {
+		return p.system.NodeID(), true
+	}
+
+	if value, found := p.cache.Load(actorID); found {
+		return value.(string), true
+	}
+
+	if !p.enabled() {
+		return "", false
+	}
+
+	nodeID, found := p.registry.Lookup(actorID)
+	if !found || nodeID == "" {
+		return "", false
+	}
+
+	p.cache.Store(actorID, nodeID)
+
+	return nodeID, true
+}
+
+func (p *location) stop() {
+	if registry, ok := p.registry.(*clusterRegistry); ok {
+		registry.stop()
+	}
+}
+
+func (p *location) invalidate(actorID string) {
+	p.cache.Delete(actorID)
+}
+
+// invalidateNode 节点移除时,清除该节点下所有actor的位置缓存
+func (p *location) invalidateNode(member cfacade.IMember) {
+	p.cache.Range(func(key, value any) bool {
+		if value == member.GetNodeID() {
+			p.cache.Delete(key)
+		}
+		return true
+	})
+}
+
+// notifyNotFound 其他节点发来的单向消息(无需回复)的目标actor不存在时,通知来源节点清除位置缓存.
+// 需要回复的消息由调用方根据返回码清除缓存
+func (p *location) notifyNotFound(m *cfacade.Message) {
+	if !p.enabled() || !m.IsCluster || m.ClusterReply != nil || p.system.app == nil {
+		return
+	}
+
+	sourcePath, err := cfacade.ToActorPath(m.Source)
+	if err != nil || sourcePath.NodeID == "" || sourcePath.NodeID == p.system.NodeID() {
+		return
+	}
+
+	packet := cproto.GetClusterPacket()
+	packet.SourcePath = cfacade.NewPath(p.system.NodeID(), locationActorID)
+	packet.TargetPath = cfacade.NewPath(sourcePath.NodeID, locationActorID)
+	packet.FuncName = actorNotFoundFuncName
+	packet.ArgBytes = []byte(m.TargetPath().ActorID)
+
+	if err = p.system.app.Cluster().PublishRemote(sourcePath.NodeID, packet); err != nil {
+		clog.Warnf("[location] Notify actor not found fail. [nodeID = %s, actorID = %s, err = %v]",
+			sourcePath.NodeID,
+			m.TargetPath().ActorID,
+			err,
+		)
+	}
+}
+
+// onRemote 处理发送给位置服务的消息
+func (p *location) onRemote(m *cfacade.Message) int32 {
+	if m.FuncName != actorNotFoundFuncName {
+		return ccode.ActorFuncNameError
+	}
+
+	argBytes, _ := m.Args.([]byte)
+	actorID := string(argBytes)
+
+	sourcePath, err := cfacade.ToActorPath(m.Source)
+	if err != nil {
+		return ccode.ActorConvertPathError
+	}
+
+	// 只清除指向通知节点的缓存,该actor可能已在其他节点重新注册
+	if value, found := p.cache.Load(actorID); found && value == sourcePath.NodeID {
+		p.invalidate(actorID)
+	}
+
+	return ccode.OK
+}
+
+// isStaleLocation 调用失败时,判断位置缓存是否可能已过期
+func isStaleLocation(code int32) bool {
+	switch code {
+	case ccode.ActorCallFail, ccode.ActorPublishRemoteError, ccode.DiscoveryNotFoundNode, ccode.RPCNetError, ccode.ActorStopping:
+		return true
+	}
+
+	return false
+}
+
+func (p *location) call(source, actorID, funcName string, arg interface{}) int32 {
+	nodeID, found := p.resolve(actorID)
+	if !found {
+		clog.Warnf("[CallByID] Actor location not found. [source = %s, actorID = %s, funcName = %s]",
+			source,
+			actorID,
+			funcName,
+		)
+		return ccode.ActorLocationNotFound
+	}
+
+	code := p.system.Call(source, cfacade.NewPath(nodeID, actorID), funcName, arg)
+	if isStaleLocation(code) {
+		p.invalidate(actorID)
+	}
+
+	return code
+}
+
+func (p *location) callWait(source, actorID, funcName string, arg, reply interface{}, chain []string) int32 {
+	nodeID, found := p.resolve(actorID)
+	if !found {
+		clog.Warnf("[CallWaitByID] Actor location not found. [source = %s, actorID = %s, funcName = %s]",
+			source,
+			actorID,
+			funcName,
+		)
+		return ccode.ActorLocationNotFound
+	}
+
+	code := p.system.callWait(source, cfacade.NewPath(nodeID, actorID), funcName, arg, reply, chain)
+	if isStaleLocation(code) {
+		p.invalidate(actorID)
+	}
+
+	return code
+}
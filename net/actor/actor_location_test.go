@@ -0,0 +1,57 @@
+package cherryActor
+
+import (
+	"sync"
+	"testing"
+)
+
+type testRegistry struct {
+	sync.Map
+	lookupCount int
+}
+
+func (p *testRegistry) Register(actorID, nodeID string) {
+	p.Store(actorID, nodeID)
+}
+
+func (p *testRegistry) Unregister(actorID, _ string) {
+	p.Delete(actorID)
+}
+
+func (p *testRegistry) Lookup(actorID string) (string, bool) {
+	p.lookupCount++
+	value, found := p.Load(actorID)
+	if !found {
+		return "", false
+	}
+	return value.(string), true
+}
+
+func TestLocationResolve(t *testing.T) {
+	registry := &testRegistry{}
+
+	system := NewSystem()
+	system.SetRegistry(registry, "player_")
+	system.CreateActor("player_1", &testActor{})
+	system.CreateActor("room_1", &testActor{})
+
+	if _, found := registry.Load("player_1"); !found {
+		t.Fatal("player actor not registered")
+	}
+
+	if _, found := registry.Load("room_1"); found {
+		t.Fatal("room actor registered")
+	}
+
+	registry.Register("player_2", "game-2")
+
+	for i := 0; i < 2; i++ {
+		if nodeID, found := system.Locate("player_2"); !found || nodeID != "game-2" {
+			t.Fatalf("nodeID = %s", nodeID)
+		}
+	}
+
+	if registry.lookupCount != 1 {
+		t.Fatalf("lookupCount = %d", registry.lookupCount)
+	}
+}
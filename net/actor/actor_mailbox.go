@@ -3,7 +3,9 @@ package cherryActor
 import (
 	"strings"
 
+	ccode "github.com/cherry-game/cherry/code"
 	cconst "github.com/cherry-game/cherry/const"
+	cerror "github.com/cherry-game/cherry/error"
 	creflect "github.com/cherry-game/cherry/extend/reflect"
 	ctime "github.com/cherry-game/cherry/extend/time"
 	cfacade "github.com/cherry-game/cherry/facade"
@@ -11,16 +13,23 @@ import (
 )
 
 type mailbox struct {
-	queue                                 // queue
-	name    string                        // 邮箱名
-	funcMap map[string]*creflect.FuncInfo // 已注册的函数
+	queue                                    // queue
+	overflow                                 // overflow policy
+	name       string                        // 邮箱名
+	funcMap    map[string]*creflect.FuncInfo // 已注册的函数
+	onDrop     func(m *cfacade.Message)      // 消息被丢弃时执行的函数
+	serializer func() cfacade.ISerializer    // 获取序列化器,用于校验函数签名
 }
 
 func newMailbox(name string) mailbox {
 	return mailbox{
-		queue:   newQueue(),
-		name:    name,
-		funcMap: make(map[string]*creflect.FuncInfo),
+		queue:    newQueue(),
+		overflow: newOverflow(),
+		name:     name,
+		funcMap:  make(map[string]*creflect.FuncInfo),
+		onDrop: func(m *cfacade.Message) {
+			replyDropped(m, ccode.ActorMailboxFull)
+		},
 	}
 }
 
@@ -53,13 +62,50 @@ func (p *mailbox) Register(funcName string, fn interface{}) {
 	p.funcMap[funcName] = &funcInfo
 }
 
+// RegisterFunc 注册函数,注册时校验函数签名
+func (p *mailbox) RegisterFunc(funcName string, fn interface{}) error {
+	if funcName == "" {
+		return cerror.Errorf("[%s] Func name is empty.", p.name)
+	}
+
+	funcName = getLastSegment(funcName)
+	funcInfo, err := creflect.GetFuncInfo(fn)
+	if err != nil {
+		return err
+	}
+
+	var serializer cfacade.ISerializer
+	if p.serializer != nil {
+		serializer = p.serializer()
+	}
+
+	if err = validateFunc(p.name, &funcInfo, serializer); err != nil {
+		return cerror.Errorf("[%s] Func signature error. [funcName = %s, err = %v]", p.name, funcName, err)
+	}
+
+	if _, found := p.funcMap[funcName]; found {
+		return cerror.Errorf("[%s] Func already exists. [funcName = %s]", p.name, funcName)
+	}
+
+	p.funcMap[funcName] = &funcInfo
+
+	return nil
+}
+
 func (p *mailbox) GetFuncInfo(funcName string) (*creflect.FuncInfo, bool) {
 	funcInfo, found := p.funcMap[funcName]
 	return funcInfo, found
 }
 
 func (p *mailbox) Pop() *cfacade.Message {
-	v := p.queue.Pop()
+	m := p.pop()
+	p.notifySpace()
+
+	return m
+}
+
+func (p *mailbox) pop() *cfacade.Message {
+	v := p.popQueue(&p.queue)
 	if v == nil {
 		return nil
 	}
@@ -73,17 +119,53 @@ func (p *mailbox) Pop() *cfacade.Message {
 	return msg
 }
 
-func (p *mailbox) Push(m *cfacade.Message) {
-	if m != nil {
-		m.PostTime = ctime.Now().ToMillisecond()
-		p.queue.Push(m)
+// Push 消息入队,返回ActorMailboxFull表示消息被丢弃或拒绝(等待回复的调用方已收到回复)
+func (p *mailbox) Push(m *cfacade.Message) int32 {
+	if m == nil {
+		return ccode.OK
 	}
+
+	m.PostTime = ctime.Now().ToMillisecond()
+
+	code, ok := p.offer(&p.queue, m, func(v interface{}) {
+		if oldest, ok := v.(*cfacade.Message); ok {
+			p.onDrop(oldest)
+			clog.Warnf("[%s] Mailbox is full, drop oldest message. [source = %s, target = %s -> %s]",
+				p.name,
+				oldest.Source,
+				oldest.Target,
+				oldest.FuncName,
+			)
+		}
+	})
+	if !ok {
+		if p.policy == DropNewestPolicy {
+			p.onDrop(m)
+		} else {
+			replyDropped(m, code)
+		}
+
+		clog.Warnf("[%s] Mailbox is full, drop message. [source = %s, target = %s -> %s, count = %d]",
+			p.name,
+			m.Source,
+			m.Target,
+			m.FuncName,
+			p.Count(),
+		)
+		return code
+	}
+
+	return ccode.OK
 }
 
-func (p *mailbox) onStop() {
+// reset 清空已注册的函数
+func (p *mailbox) reset() {
 	for key := range p.funcMap {
 		delete(p.funcMap, key)
 	}
+}
 
+func (p *mailbox) onStop() {
+	p.reset()
 	p.queue.Destroy()
 }
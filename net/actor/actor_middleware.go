@@ -0,0 +1,102 @@
+package cherryActor
+
+import (
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+type (
+	// invokeChain 中间件链,第一个中间件在最外层执行
+	invokeChain struct {
+		middlewares []cfacade.InvokeMiddleware
+		local       cfacade.InvokeFunc // 已组装的local调用链
+		remote      cfacade.InvokeFunc // 已组装的remote调用链
+		event       cfacade.InvokeFunc // 已组装的event调用链
+	}
+)
+
+func (p *invokeChain) use(middlewares ...cfacade.InvokeMiddleware) {
+	for _, middleware := range middlewares {
+		if middleware != nil {
+			p.middlewares = append(p.middlewares, middleware)
+		}
+	}
+
+	p.local = nil
+	p.remote = nil
+	p.event = nil
+}
+
+func (p *invokeChain) reset() {
+	p.middlewares = nil
+	p.local = nil
+	p.remote = nil
+	p.event = nil
+}
+
+// chainInvoke 使用中间件包装invoke函数
+func chainInvoke(invoke cfacade.InvokeFunc, middlewares ...cfacade.InvokeMiddleware) cfacade.InvokeFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		invoke = middlewares[i](invoke)
+	}
+
+	return invoke
+}
+
+// localInvoke system中间件 -> actor中间件 -> local invoke func
+func (p *Actor) localInvoke() cfacade.InvokeFunc {
+	if len(p.invokeChain.middlewares) < 1 {
+		return p.system.invokeChain.local
+	}
+
+	if p.invokeChain.local == nil {
+		middlewares := append(p.system.middlewares(), p.invokeChain.middlewares...)
+		p.invokeChain.local = chainInvoke(p.system.localInvokeFunc, middlewares...)
+	}
+
+	return p.invokeChain.local
+}
+
+// remoteInvoke system中间件 -> actor中间件 -> remote invoke func
+func (p *Actor) remoteInvoke() cfacade.InvokeFunc {
+	if len(p.invokeChain.middlewares) < 1 {
+		return p.system.invokeChain.remote
+	}
+
+	if p.invokeChain.remote == nil {
+		middlewares := append(p.system.middlewares(), p.invokeChain.middlewares...)
+		p.invokeChain.remote = chainInvoke(p.system.remoteInvokeFunc, middlewares...)
+	}
+
+	return p.invokeChain.remote
+}
+
+// eventInvoke system中间件 -> actor中间件 -> event invoke func
+func (p *Actor) eventInvoke() cfacade.InvokeFunc {
+	if len(p.invokeChain.middlewares) < 1 {
+		return p.system.invokeChain.event
+	}
+
+	if p.invokeChain.event == nil {
+		middlewares := append(p.system.middlewares(), p.invokeChain.middlewares...)
+		p.invokeChain.event = chainInvoke(InvokeEventFunc, middlewares...)
+	}
+
+	return p.invokeChain.event
+}
+
+// Use 添加当前actor的中间件,在system中间件之后执行.需要在OnInit()中调用
+func (p *Actor) Use(middlewares ...cfacade.InvokeMiddleware) {
+	p.invokeChain.use(middlewares...)
+}
+
+func (p *System) middlewares() []cfacade.InvokeMiddleware {
+	middlewares := make([]cfacade.InvokeMiddleware, len(p.invokeChain.middlewares))
+	copy(middlewares, p.invokeChain.middlewares)
+	return middlewares
+}
+
+func (p *System) buildInvokeChain() {
+	p.invokeChain.local = chainInvoke(p.localInvokeFunc, p.invokeChain.middlewares...)
+	p.invokeChain.remote = chainInvoke(p.remoteInvokeFunc, p.invokeChain.middlewares...)
+	p.invokeChain.event = chainInvoke(InvokeEventFunc, p.invokeChain.middlewares...)
+}
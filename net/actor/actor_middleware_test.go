@@ -0,0 +1,98 @@
+package cherryActor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	creflect "github.com/cherry-game/cherry/extend/reflect"
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+func TestInvokeMiddlewareOrder(t *testing.T) {
+	var trace []string
+
+	record := func(name string) cfacade.InvokeMiddleware {
+		return func(next cfacade.InvokeFunc) cfacade.InvokeFunc {
+			return func(app cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade.Message) {
+				trace = append(trace, name)
+				next(app, fi, m)
+			}
+		}
+	}
+
+	system := NewSystem()
+	system.SetLocalInvoke(func(_ cfacade.IApplication, _ *creflect.FuncInfo, _ *cfacade.Message) {
+		trace = append(trace, "invoke")
+	})
+	system.Use(record("system1"), record("system2"))
+
+	thisActor := &Actor{system: system}
+	thisActor.localInvoke()(nil, nil, nil)
+	if got := strings.Join(trace, ","); got != "system1,system2,invoke" {
+		t.Fatalf("trace = %s", got)
+	}
+
+	trace = nil
+	thisActor.Use(record("actor"))
+	thisActor.localInvoke()(nil, nil, nil)
+	if got := strings.Join(trace, ","); got != "system1,system2,actor,invoke" {
+		t.Fatalf("trace = %s", got)
+	}
+}
+
+type (
+	testEventData struct{}
+
+	testEventActor struct {
+		Base
+		done chan struct{}
+	}
+)
+
+func (*testEventData) Name() string {
+	return "testEvent"
+}
+
+func (*testEventData) UniqueID() int64 {
+	return 0
+}
+
+func (p *testEventActor) OnInit() {
+	p.Event().Register("testEvent", func(_ cfacade.IEventData) {
+		close(p.done)
+	})
+}
+
+func TestInvokeMiddlewareEvent(t *testing.T) {
+	funcNames := make(chan string, 1)
+
+	system := NewSystem()
+	system.Use(func(next cfacade.InvokeFunc) cfacade.InvokeFunc {
+		return func(app cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade.Message) {
+			// 中间件可以读取事件的session及函数信息
+			if m.Session == nil || !fi.Value.IsValid() || fi.Value.IsNil() || fi.InArgsLen != 1 {
+				t.Errorf("session = %v, funcInfo = %+v", m.Session, fi)
+			}
+			funcNames <- m.FuncName
+			next(app, fi, m)
+		}
+	})
+	defer system.Stop()
+
+	handler := &testEventActor{done: make(chan struct{})}
+	thisActor, _ := system.CreateActor("event", handler)
+	waitInit(t, thisActor.(*Actor))
+
+	system.PostEvent(&testEventData{})
+
+	select {
+	case <-handler.done:
+	case <-time.After(time.Second):
+		t.Fatal("event not invoked")
+	}
+
+	if funcName := <-funcNames; funcName != "testEvent" {
+		t.Fatalf("funcName = %s", funcName)
+	}
+}
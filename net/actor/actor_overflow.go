@@ -0,0 +1,171 @@
+package cherryActor
+
+import (
+	"sync"
+	"time"
+
+	ccode "github.com/cherry-game/cherry/code"
+	cfacade "github.com/cherry-game/cherry/facade"
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+var (
+	DropNewestPolicy OverflowPolicy = 0 // 丢弃新消息并转为死信,调用方返回ActorMailboxFull
+	DropOldestPolicy OverflowPolicy = 1 // 入队时丢弃队列中最旧的消息(等待回复的调用方收到ActorMailboxFull)
+	RejectPolicy     OverflowPolicy = 2 // 拒绝新消息,调用方直接返回ActorMailboxFull
+	BlockPolicy      OverflowPolicy = 3 // 阻塞等待队列空闲,超时后拒绝
+)
+
+const (
+	defaultBlockTimeout = 100 * time.Millisecond
+)
+
+type (
+	OverflowPolicy int
+
+	// overflow 有界队列的溢出策略
+	overflow struct {
+		capacity     int32          // 队列容量(<1表示无界)
+		policy       OverflowPolicy // 溢出策略
+		blockTimeout time.Duration  // BlockPolicy的最大等待时间
+		space        chan struct{}  // 消费者出队时通知阻塞的生产者
+		popLock      sync.Mutex     // DropOldestPolicy的生产者会出队最旧的数据,与消费者互斥
+		pushLock     sync.Mutex     // 有界队列的生产者互斥,容量检查与入队为原子操作
+	}
+)
+
+func newOverflow() overflow {
+	return overflow{
+		capacity:     0,
+		policy:       DropNewestPolicy,
+		blockTimeout: defaultBlockTimeout,
+		space:        make(chan struct{}, 1),
+	}
+}
+
+// SetCapacity 设置队列容量及溢出策略
+// capacity 队列容量,小于1表示无界队列
+// policy 溢出策略
+// blockTimeout BlockPolicy的最大等待时间
+func (p *overflow) SetCapacity(capacity int32, policy OverflowPolicy, blockTimeout ...time.Duration) {
+	p.capacity = capacity
+	p.policy = policy
+
+	if len(blockTimeout) > 0 && blockTimeout[0] > 0 {
+		p.blockTimeout = blockTimeout[0]
+	}
+}
+
+func (p *overflow) bounded() bool {
+	return p.capacity > 0
+}
+
+func (p *overflow) isFull(q *queue) bool {
+	return p.bounded() && q.Count() >= p.capacity
+}
+
+// isOverflow 队列数量超过容量
+func (p *overflow) isOverflow(q *queue) bool {
+	return p.bounded() && q.Count() > p.capacity
+}
+
+// popQueue 消费者出队
+func (p *overflow) popQueue(q *queue) interface{} {
+	if p.policy != DropOldestPolicy {
+		return q.Pop()
+	}
+
+	p.popLock.Lock()
+	defer p.popLock.Unlock()
+
+	return q.Pop()
+}
+
+// evictOldest DropOldestPolicy在入队后丢弃超出容量的最旧数据,消费者停滞时队列也不会无限增长
+func (p *overflow) evictOldest(q *queue, onEvict func(v interface{})) {
+	if p.policy != DropOldestPolicy || !p.isOverflow(q) {
+		return
+	}
+
+	p.popLock.Lock()
+	defer p.popLock.Unlock()
+
+	for p.isOverflow(q) {
+		v := q.Pop()
+		if v == nil {
+			return
+		}
+		onEvict(v)
+	}
+}
+
+// offer 按溢出策略入队,容量检查与入队在pushLock中执行,并发的生产者不会超出容量
+// onEvict DropOldestPolicy丢弃最旧数据时调用
+func (p *overflow) offer(q *queue, v interface{}, onEvict func(v interface{})) (int32, bool) {
+	if !p.bounded() {
+		q.Push(v)
+		return ccode.OK, true
+	}
+
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		p.pushLock.Lock()
+		if !p.isFull(q) || p.policy == DropOldestPolicy {
+			q.Push(v)
+			p.pushLock.Unlock()
+
+			p.evictOldest(q, onEvict)
+			return ccode.OK, true
+		}
+		p.pushLock.Unlock()
+
+		// DropNewestPolicy、RejectPolicy
+		if p.policy != BlockPolicy {
+			return ccode.ActorMailboxFull, false
+		}
+
+		if timer == nil {
+			timer = time.NewTimer(p.blockTimeout)
+		}
+
+		select {
+		case <-p.space:
+		case <-timer.C:
+			return ccode.ActorMailboxFull, false
+		}
+	}
+}
+
+// notifySpace 消费者出队后唤醒阻塞的生产者
+func (p *overflow) notifySpace() {
+	if p.policy != BlockPolicy {
+		return
+	}
+
+	select {
+	case p.space <- struct{}{}:
+	default:
+	}
+}
+
+// replyDropped 通知被丢弃消息的调用方
+func replyDropped(m *cfacade.Message, code int32) {
+	rsp := &cproto.Response{
+		Code: code,
+	}
+
+	if m.ChanResult != nil {
+		select {
+		case m.ChanResult <- rsp:
+		default:
+		}
+	}
+
+	retResponse(m.ClusterReply, rsp)
+}
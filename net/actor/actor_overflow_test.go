@@ -0,0 +1,112 @@
+package cherryActor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	ccode "github.com/cherry-game/cherry/code"
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+func newTestMessage(funcName string) *cfacade.Message {
+	m := cfacade.GetMessage()
+	m.FuncName = funcName
+	return &m
+}
+
+func TestMailboxReject(t *testing.T) {
+	mb := newMailbox(RemoteName)
+	mb.SetCapacity(2, RejectPolicy)
+
+	for i := 0; i < 2; i++ {
+		if code := mb.Push(newTestMessage("ok")); ccode.IsFail(code) {
+			t.Fatalf("push %d fail. code = %d", i, code)
+		}
+	}
+
+	if code := mb.Push(newTestMessage("full")); code != ccode.ActorMailboxFull {
+		t.Fatalf("code = %d", code)
+	}
+}
+
+func TestMailboxDropNewest(t *testing.T) {
+	mb := newMailbox(RemoteName)
+	mb.SetCapacity(1, DropNewestPolicy)
+	mb.Push(newTestMessage("1"))
+
+	newest := newTestMessage("2")
+	newest.ChanResult = make(chan interface{}, 1)
+
+	if code := mb.Push(newest); code != ccode.ActorMailboxFull {
+		t.Fatalf("code = %d", code)
+	}
+
+	if len(newest.ChanResult) != 1 {
+		t.Fatal("dropped message not replied")
+	}
+}
+
+func TestMailboxDropOldest(t *testing.T) {
+	mb := newMailbox(RemoteName)
+	mb.SetCapacity(2, DropOldestPolicy)
+
+	oldest := newTestMessage("1")
+	oldest.ChanResult = make(chan interface{}, 1)
+	mb.Push(oldest)
+	mb.Push(newTestMessage("2"))
+	mb.Push(newTestMessage("3"))
+
+	// 入队时已丢弃最旧的消息
+	if count := mb.Count(); count != 2 {
+		t.Fatalf("count = %d", count)
+	}
+
+	if len(oldest.ChanResult) != 1 {
+		t.Fatal("dropped message not replied")
+	}
+
+	if m := mb.Pop(); m.FuncName != "2" {
+		t.Fatalf("funcName = %s", m.FuncName)
+	}
+}
+
+func TestMailboxBlock(t *testing.T) {
+	mb := newMailbox(RemoteName)
+	mb.SetCapacity(1, BlockPolicy, 500*time.Millisecond)
+	mb.Push(newTestMessage("1"))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		mb.Pop()
+	}()
+
+	if code := mb.Push(newTestMessage("2")); ccode.IsFail(code) {
+		t.Fatalf("code = %d", code)
+	}
+}
+
+func TestMailboxConcurrentBound(t *testing.T) {
+	for _, policy := range []OverflowPolicy{DropNewestPolicy, RejectPolicy} {
+		mb := newMailbox(RemoteName)
+		mb.onDrop = func(_ *cfacade.Message) {}
+		mb.SetCapacity(10, policy)
+
+		// 并发的生产者不能超出容量
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					mb.Push(newTestMessage("ok"))
+				}
+			}()
+		}
+		wg.Wait()
+
+		if count := mb.Count(); count != 10 {
+			t.Fatalf("policy = %d, count = %d", policy, count)
+		}
+	}
+}
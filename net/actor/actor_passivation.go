@@ -0,0 +1,194 @@
+package cherryActor
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	ctime "github.com/cherry-game/cherry/extend/time"
+	ctimeWheel "github.com/cherry-game/cherry/extend/time_wheel"
+	cfacade "github.com/cherry-game/cherry/facade"
+	clog "github.com/cherry-game/cherry/logger"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	minPassivateInterval = time.Second
+)
+
+type (
+	// ActorFactory 根据actorID创建actor handler,返回nil表示不创建
+	ActorFactory func(actorID string) cfacade.IActorHandler
+
+	// passivation 空闲actor钝化(停止并移除),收到消息时通过factory重新激活
+	//
+	// 只有actorID匹配已注册factory前缀的顶层actor才会被钝化.
+	// 钝化中的actor执行完OnStop(保存快照)后,才会重新激活同id的actor
+	passivation struct {
+		sync.RWMutex
+		system    *System
+		factories map[string]ActorFactory // key:actorID prefix, value:factory
+		ttl       time.Duration           // 空闲时间
+		timer     *ctimeWheel.Timer       // 检查空闲actor的定时器
+		stopping  map[string]*Actor       // 钝化中(未执行完onStop)的actor
+	}
+)
+
+func newPassivation(system *System) *passivation {
+	return &passivation{
+		system:    system,
+		factories: make(map[string]ActorFactory),
+		stopping:  make(map[string]*Actor),
+	}
+}
+
+func (p *passivation) register(prefix string, factory ActorFactory) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.factories[prefix] = factory
+}
+
+// findFactory 根据actorID查找最长匹配前缀的factory
+func (p *passivation) findFactory(actorID string) (ActorFactory, bool) {
+	p.RLock()
+	defer p.RUnlock()
+
+	var (
+		factory   ActorFactory
+		prefixLen = -1
+	)
+
+	for prefix, fn := range p.factories {
+		if len(prefix) > prefixLen && strings.HasPrefix(actorID, prefix) {
+			factory = fn
+			prefixLen = len(prefix)
+		}
+	}
+
+	return factory, factory != nil
+}
+
+func (p *passivation) setTTL(ttl time.Duration) {
+	p.stop()
+
+	p.Lock()
+	p.ttl = ttl
+	p.Unlock()
+
+	if ttl <= 0 {
+		return
+	}
+
+	interval := ttl / 10
+	if interval < minPassivateInterval {
+		interval = minPassivateInterval
+	}
+
+	p.timer = globalTimer.BuildEveryFunc(interval, p.check, true)
+}
+
+// check 钝化空闲时间超过ttl的actor
+func (p *passivation) check() {
+	p.RLock()
+	ttl := p.ttl
+	p.RUnlock()
+
+	if ttl <= 0 {
+		return
+	}
+
+	deadline := ctime.Now().Add(-ttl).Unix()
+
+	p.system.actorMap.Range(func(key, value any) bool {
+		thisActor, ok := value.(*Actor)
+		if !ok || thisActor.State() != WorkerState || thisActor.LastAt() > deadline {
+			return true
+		}
+
+		if _, found := p.findFactory(thisActor.ActorID()); !found {
+			return true
+		}
+
+		if thisActor.localMail.Count() > 0 || thisActor.remoteMail.Count() > 0 || thisActor.event.Count() > 0 {
+			return true
+		}
+
+		// 先从system中移除,新消息到达时等待onStop执行完成后重新激活
+		p.Lock()
+		p.stopping[thisActor.ActorID()] = thisActor
+		p.Unlock()
+
+		p.system.removeActor(thisActor)
+		// 不阻塞定时器:actor忙碌或已在退出中时,close channel中已有退出信号
+		thisActor.exit()
+
+		if clog.PrintLevel(zapcore.DebugLevel) {
+			clog.Debugf("[passivation] actor passivated. [path = %s, lastAt = %d]", thisActor.path, thisActor.LastAt())
+		}
+
+		return true
+	})
+}
+
+// activate 通过factory重新创建actor
+func (p *passivation) activate(actorID string) (*Actor, bool) {
+	factory, found := p.findFactory(actorID)
+	if !found {
+		return nil, false
+	}
+
+	if !p.waitStopped(actorID) {
+		return nil, false
+	}
+
+	handler := factory(actorID)
+	if handler == nil {
+		return nil, false
+	}
+
+	iActor, err := p.system.createActor(actorID, handler, factory)
+	if err != nil {
+		clog.Warnf("[passivation] activate actor fail. [actorID = %s, err = %v]", actorID, err)
+		return nil, false
+	}
+
+	thisActor, ok := iActor.(*Actor)
+	return thisActor, ok
+}
+
+// waitStopped 等待钝化中的同id actor停止,避免新旧actor同时运行(旧actor保存的快照覆盖新actor的状态)
+func (p *passivation) waitStopped(actorID string) bool {
+	p.RLock()
+	oldActor, found := p.stopping[actorID]
+	p.RUnlock()
+
+	if !found {
+		return true
+	}
+
+	select {
+	case <-oldActor.stopped:
+		return true
+	case <-time.After(p.system.stopTimeout):
+		clog.Warnf("[passivation] wait passivated actor stop timeout. [actorID = %s]", actorID)
+		return false
+	}
+}
+
+// onStopped actor执行完onStop后移除钝化记录
+func (p *passivation) onStopped(thisActor *Actor) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.stopping[thisActor.ActorID()] == thisActor {
+		delete(p.stopping, thisActor.ActorID())
+	}
+}
+
+func (p *passivation) stop() {
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+}
@@ -0,0 +1,197 @@
+package cherryActor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ctime "github.com/cherry-game/cherry/extend/time"
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+type testPassivateActor struct {
+	Base
+	inits       *int32
+	stopEntered chan struct{}
+	release     chan struct{}
+	stopDone    *int32
+	initAfter   chan bool // 第二个实例OnInit时,第一个实例是否已执行完OnStop
+}
+
+func (p *testPassivateActor) OnInit() {
+	if atomic.AddInt32(p.inits, 1) == 2 {
+		p.initAfter <- atomic.LoadInt32(p.stopDone) == 1
+	}
+}
+
+func (p *testPassivateActor) OnStop() {
+	if atomic.LoadInt32(p.inits) == 1 {
+		close(p.stopEntered)
+		<-p.release
+		atomic.StoreInt32(p.stopDone, 1)
+	}
+}
+
+// waitPassivated 等待actor被钝化检查定时器移除
+func waitPassivated(t *testing.T, system *System, actorID string) {
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, found := system.GetActor(actorID); !found {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("actor not passivated")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPassivationActivate(t *testing.T) {
+	clock := ctime.NewManualClock(time.Time{})
+	ctime.SetClock(clock)
+	defer ctime.SetClock(nil)
+
+	system := NewSystem()
+	system.RegisterFactory("player_", func(actorID string) cfacade.IActorHandler {
+		return &testActor{}
+	})
+	system.SetPassivateTTL(time.Minute)
+	defer system.SetPassivateTTL(0)
+
+	m := newTestMessage("login")
+	m.Target = ".player_1001"
+
+	if !system.PostRemote(m) {
+		t.Fatal("actor not activated")
+	}
+
+	thisActor, found := system.GetActor("player_1001")
+	if !found {
+		t.Fatal("actor not found")
+	}
+	waitInit(t, thisActor)
+
+	clock.Advance(30 * time.Second)
+	if _, found := system.GetActor("player_1001"); !found {
+		t.Fatal("actor passivated before ttl")
+	}
+
+	clock.Advance(time.Minute)
+	waitPassivated(t, system, "player_1001")
+}
+
+func TestPassivationReactivateAfterStop(t *testing.T) {
+	clock := ctime.NewManualClock(time.Time{})
+	ctime.SetClock(clock)
+	defer ctime.SetClock(nil)
+
+	var inits, stopDone int32
+	stopEntered := make(chan struct{})
+	release := make(chan struct{})
+	initAfter := make(chan bool, 1)
+
+	system := NewSystem()
+	system.RegisterFactory("player_", func(actorID string) cfacade.IActorHandler {
+		return &testPassivateActor{
+			inits:       &inits,
+			stopEntered: stopEntered,
+			release:     release,
+			stopDone:    &stopDone,
+			initAfter:   initAfter,
+		}
+	})
+	system.SetPassivateTTL(time.Minute)
+	defer system.SetPassivateTTL(0)
+
+	m := newTestMessage("login")
+	m.Target = ".player_1001"
+	system.PostRemote(m)
+
+	thisActor, _ := system.GetActor("player_1001")
+	waitInit(t, thisActor)
+
+	clock.Advance(2 * time.Minute)
+	<-stopEntered
+
+	// 旧actor执行OnStop期间收到消息,等待其停止后再激活
+	go func() {
+		m := newTestMessage("login")
+		m.Target = ".player_1001"
+		system.PostRemote(m)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	if atomic.LoadInt32(&inits) != 1 {
+		t.Fatal("actor reactivated before the old one stopped")
+	}
+	close(release)
+
+	select {
+	case after := <-initAfter:
+		if !after {
+			t.Fatal("two actor instances are running")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("actor not reactivated")
+	}
+}
+
+type testBusyActor struct {
+	Base
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (p *testBusyActor) OnInit() {
+	p.Remote().Register("block", func() {
+		close(p.entered)
+		<-p.release
+	})
+}
+
+func TestPassivationNotBlockTimer(t *testing.T) {
+	clock := ctime.NewManualClock(time.Time{})
+	ctime.SetClock(clock)
+	defer ctime.SetClock(nil)
+
+	handler := &testBusyActor{
+		entered: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	defer close(handler.release)
+
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+	system.RegisterFactory("busy_", func(actorID string) cfacade.IActorHandler {
+		return handler
+	})
+	system.passivation.ttl = time.Minute
+
+	m := newTestMessage("block")
+	m.Target = ".busy_1001"
+	system.PostRemote(m)
+	<-handler.entered
+
+	// actor执行函数期间已在退出中,钝化检查不能阻塞全局定时器
+	thisActor, _ := system.GetActor("busy_1001")
+	thisActor.Exit()
+
+	clock.Advance(2 * time.Minute)
+
+	done := make(chan struct{})
+	go func() {
+		system.passivation.check()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("passivation check blocked the global timer")
+	}
+
+	if _, found := system.GetActor("busy_1001"); found {
+		t.Fatal("actor not passivated")
+	}
+}
@@ -0,0 +1,60 @@
+package cherryActor
+
+import (
+	ccode "github.com/cherry-game/cherry/code"
+	cfacade "github.com/cherry-game/cherry/facade"
+	clog "github.com/cherry-game/cherry/logger"
+)
+
+// 控制消息(cfacade.Message.Priority = true)进入优先通道,不受邮箱容量限制,
+// 在local/remote/event消息之前处理.框架的定时器及Kick使用优先通道,
+// 业务可以通过CallPriority()发送关闭通知等控制消息
+
+// pushLocal 投递消息到local邮箱,控制消息进入优先通道
+func (p *Actor) pushLocal(m *cfacade.Message) int32 {
+	return p.push(p.localMail, m)
+}
+
+// pushRemote 投递消息到remote邮箱,控制消息进入优先通道
+func (p *Actor) pushRemote(m *cfacade.Message) int32 {
+	return p.push(p.remoteMail, m)
+}
+
+func (p *Actor) push(mb *mailbox, m *cfacade.Message) int32 {
+	if m != nil && m.Priority {
+		p.priority.Push(&envelope{
+			mb:      mb,
+			message: m,
+		})
+		return ccode.OK
+	}
+
+	return mb.Push(m)
+}
+
+// processPriority 处理一条控制消息
+func (p *Actor) processPriority() {
+	v := p.priority.Pop()
+	if v == nil {
+		return
+	}
+
+	item, ok := v.(*envelope)
+	if !ok {
+		clog.Warnf("Convert to *envelope fail. v = %+v", v)
+		return
+	}
+
+	p.setLastAt()
+
+	if item.mb == p.localMail {
+		p.receiveLocal(item.message)
+	} else {
+		p.receiveRemote(item.message)
+	}
+}
+
+// CallPriority 发送控制消息,目标actor优先处理
+func (p *Actor) CallPriority(targetPath, funcName string, arg interface{}) int32 {
+	return p.system.CallPriority(p.PathString(), targetPath, funcName, arg)
+}
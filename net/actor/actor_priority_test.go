@@ -0,0 +1,54 @@
+package cherryActor
+
+import (
+	"testing"
+	"time"
+)
+
+type testPriorityActor struct {
+	Base
+	blocked chan struct{}
+	order   chan string
+}
+
+func (p *testPriorityActor) OnInit() {
+	p.Remote().Register("block", func() {
+		<-p.blocked
+	})
+	p.Remote().Register("work", func() {
+		p.order <- "work"
+	})
+	p.Remote().Register("kick", func() {
+		p.order <- "kick"
+	})
+}
+
+func TestPriorityLane(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+
+	handler := &testPriorityActor{
+		blocked: make(chan struct{}),
+		order:   make(chan string, 3),
+	}
+	system.CreateActor("agent", handler)
+
+	system.Call(".caller", ".agent", "block", nil)
+	time.Sleep(10 * time.Millisecond)
+
+	system.Call(".caller", ".agent", "work", nil)
+	system.Call(".caller", ".agent", "work", nil)
+	system.CallPriority(".caller", ".agent", "kick", nil)
+	close(handler.blocked)
+
+	for i, want := range []string{"kick", "work", "work"} {
+		select {
+		case got := <-handler.order:
+			if got != want {
+				t.Fatalf("index = %d, got = %s, want = %s", i, got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package cherryActor
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ccode "github.com/cherry-game/cherry/code"
+	ctimeWheel "github.com/cherry-game/cherry/extend/time_wheel"
+	cfacade "github.com/cherry-game/cherry/facade"
+	clog "github.com/cherry-game/cherry/logger"
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+const (
+	RegistryActorID      = "actorRegistry"       // 注册中心actor id
+	registryClientID     = "actorRegistryClient" // 注册中心客户端的来源actor id
+	registerFuncName     = "register"
+	unregisterFuncName   = "unregister"
+	lookupFuncName       = "lookup"
+	removeNodeFuncName   = "removeNode"
+	registrySyncInterval = 3 * time.Second
+)
+
+type (
+	// registryActor 注册中心actor,记录actorID所在的节点
+	registryActor struct {
+		Base
+		locations map[string]string // key:actorID, value:nodeID
+		listened  bool              // 已注册节点移除的监听(重启时不重复注册)
+	}
+
+	// clusterRegistry 通过注册中心actor实现的IActorRegistry
+	//
+	// 注册中心actor运行在指定类型的节点上(按nodeID排序取第一个节点),
+	// 注册失败或注册中心节点变化时,定时重新注册本节点的所有actor
+	clusterRegistry struct {
+		system   *System
+		nodeType string    // 注册中心actor所在的节点类型
+		locals   sync.Map  // 本节点已注册的actor. key:actorID, value:nodeID
+		dirty    int32     // 是否需要重新注册
+		once     sync.Once // 延迟初始化
+		timer    *ctimeWheel.Timer
+	}
+)
+
+// NewRegistryActor 创建注册中心actor
+func NewRegistryActor() *registryActor {
+	return &registryActor{
+		locations: make(map[string]string),
+	}
+}
+
+func (p *registryActor) AliasID() string {
+	return RegistryActorID
+}
+
+func (p *registryActor) OnInit() {
+	p.Remote().Register(registerFuncName, p.register)
+	p.Remote().Register(unregisterFuncName, p.unregister)
+	p.Remote().Register(lookupFuncName, p.lookup)
+	p.Remote().Register(removeNodeFuncName, p.removeNode)
+
+	if discovery := p.App().Discovery(); discovery != nil && !p.listened {
+		p.listened = true
+		discovery.OnRemoveMember(func(member cfacade.IMember) {
+			p.Call(p.PathString(), removeNodeFuncName, &cproto.ActorLocation{
+				NodeID: member.GetNodeID(),
+			})
+		})
+	}
+}
+
+func (p *registryActor) register(req *cproto.ActorLocation) {
+	p.locations[req.ActorID] = req.NodeID
+}
+
+func (p *registryActor) unregister(req *cproto.ActorLocation) {
+	// 只移除同一节点注册的actor,避免覆盖其他节点的新注册
+	if nodeID, found := p.locations[req.ActorID]; found && nodeID == req.NodeID {
+		delete(p.locations, req.ActorID)
+	}
+}
+
+func (p *registryActor) lookup(req *cproto.ActorLocation) (*cproto.ActorLocation, int32) {
+	nodeID, found := p.locations[req.ActorID]
+	if !found {
+		return nil, ccode.ActorLocationNotFound
+	}
+
+	return &cproto.ActorLocation{
+		ActorID: req.ActorID,
+		NodeID:  nodeID,
+	}, ccode.OK
+}
+
+func (p *registryActor) removeNode(req *cproto.ActorLocation) {
+	for actorID, nodeID := range p.locations {
+		if nodeID == req.NodeID {
+			delete(p.locations, actorID)
+		}
+	}
+}
+
+// NewClusterRegistry 创建基于注册中心actor的IActorRegistry
+// nodeType 运行注册中心actor的节点类型
+func NewClusterRegistry(system *System, nodeType string) IActorRegistry {
+	return &clusterRegistry{
+		system:   system,
+		nodeType: nodeType,
+	}
+}
+
+func (p *clusterRegistry) init() {
+	p.once.Do(func() {
+		if discovery := p.system.app.Discovery(); discovery != nil {
+			discovery.OnAddMember(func(member cfacade.IMember) {
+				if member.GetNodeType() == p.nodeType {
+					atomic.StoreInt32(&p.dirty, 1)
+				}
+			})
+		}
+
+		p.timer = globalTimer.BuildEveryFunc(registrySyncInterval, p.sync, true)
+	})
+}
+
+// registryPath 获取注册中心actor的path
+func (p *clusterRegistry) registryPath() (string, bool) {
+	discovery := p.system.app.Discovery()
+	if discovery == nil {
+		return "", false
+	}
+
+	memberList := discovery.ListByType(p.nodeType)
+	if len(memberList) < 1 {
+		return "", false
+	}
+
+	sort.Slice(memberList, func(i, j int) bool {
+		return memberList[i].GetNodeID() < memberList[j].GetNodeID()
+	})
+
+	return cfacade.NewPath(memberList[0].GetNodeID(), RegistryActorID), true
+}
+
+func (p *clusterRegistry) sourcePath() string {
+	return cfacade.NewPath(p.system.NodeID(), registryClientID)
+}
+
+func (p *clusterRegistry) call(funcName, actorID, nodeID string) int32 {
+	targetPath, found := p.registryPath()
+	if !found {
+		return ccode.DiscoveryNotFoundNode
+	}
+
+	return p.system.Call(p.sourcePath(), targetPath, funcName, &cproto.ActorLocation{
+		ActorID: actorID,
+		NodeID:  nodeID,
+	})
+}
+
+func (p *clusterRegistry) Register(actorID, nodeID string) {
+	p.init()
+	p.locals.Store(actorID, nodeID)
+
+	if code := p.call(registerFuncName, actorID, nodeID); ccode.IsFail(code) {
+		atomic.StoreInt32(&p.dirty, 1)
+	}
+}
+
+func (p *clusterRegistry) Unregister(actorID, nodeID string) {
+	p.locals.Delete(actorID)
+	p.call(unregisterFuncName, actorID, nodeID)
+}
+
+func (p *clusterRegistry) Lookup(actorID string) (string, bool) {
+	targetPath, found := p.registryPath()
+	if !found {
+		return "", false
+	}
+
+	req := &cproto.ActorLocation{
+		ActorID: actorID,
+	}
+
+	rsp := &cproto.ActorLocation{}
+	code := p.system.CallWait(p.sourcePath(), targetPath, lookupFuncName, req, rsp)
+	if ccode.IsFail(code) {
+		if code != ccode.ActorLocationNotFound {
+			clog.Warnf("[clusterRegistry] Lookup fail. [actorID = %s, code = %d]", actorID, code)
+		}
+		return "", false
+	}
+
+	return rsp.NodeID, rsp.NodeID != ""
+}
+
+// sync 重新注册本节点的所有actor
+func (p *clusterRegistry) sync() {
+	if p.system.app == nil || !p.system.app.Running() {
+		return
+	}
+
+	if !atomic.CompareAndSwapInt32(&p.dirty, 1, 0) {
+		return
+	}
+
+	p.locals.Range(func(key, value any) bool {
+		if code := p.call(registerFuncName, key.(string), value.(string)); ccode.IsFail(code) {
+			atomic.StoreInt32(&p.dirty, 1)
+			return false
+		}
+		return true
+	})
+}
+
+func (p *clusterRegistry) stop() {
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+}
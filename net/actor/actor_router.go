@@ -0,0 +1,317 @@
+package cherryActor
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	cerror "github.com/cherry-game/cherry/error"
+	cfacade "github.com/cherry-game/cherry/facade"
+	clog "github.com/cherry-game/cherry/logger"
+	csharding "github.com/cherry-game/cherry/net/sharding"
+)
+
+var (
+	RoundRobinRouter  RouterStrategy = 0 // 轮询
+	LeastLoadedRouter RouterStrategy = 1 // 邮箱中消息数量最少的成员
+	HashRouter        RouterStrategy = 2 // 根据key一致性hash
+)
+
+const (
+	poolMemberSeparator = "#" // 成员actorID = poolID#index
+)
+
+type (
+	RouterStrategy int
+
+	// RouterHashKey 获取消息的hash key(HashRouter)
+	RouterHashKey func(m *cfacade.Message) string
+
+	// Pool 路由池,将发送给poolID的消息转发给N个相同的成员actor
+	//
+	// 成员actor为顶层actor,停止后由路由池重新创建
+	Pool struct {
+		sync.RWMutex
+		system   *System
+		poolID   string
+		factory  ActorFactory
+		strategy RouterStrategy
+		hashKey  RouterHashKey
+		members  []string        // 成员actorID
+		ring     *csharding.Ring // HashRouter的一致性hash环
+		counter  uint64          // RoundRobinRouter计数
+		stopped  bool
+	}
+
+	routers struct {
+		pools sync.Map // key:poolID, value:*Pool
+	}
+)
+
+// defaultHashKey 优先使用session uid,否则使用来源actor path
+func defaultHashKey(m *cfacade.Message) string {
+	if m.Session != nil && m.Session.Uid > 0 {
+		return strconv.FormatInt(m.Session.Uid, 10)
+	}
+
+	return m.Source
+}
+
+func (p *routers) get(poolID string) (*Pool, bool) {
+	value, found := p.pools.Load(poolID)
+	if !found {
+		return nil, false
+	}
+
+	return value.(*Pool), true
+}
+
+// route 选择路由池的成员actor
+func (p *routers) route(poolID string, m *cfacade.Message) (*Actor, bool) {
+	pool, found := p.get(poolID)
+	if !found {
+		return nil, false
+	}
+
+	return pool.route(m)
+}
+
+// onStop 成员actor停止后重新创建(监督)
+func (p *routers) onStop(thisActor *Actor) {
+	poolID, _, found := cutPoolMember(thisActor.ActorID())
+	if !found {
+		return
+	}
+
+	if pool, found := p.get(poolID); found {
+		pool.supervise(thisActor.ActorID())
+	}
+}
+
+func (p *routers) stop() {
+	p.pools.Range(func(key, value any) bool {
+		value.(*Pool).markStopped()
+		return true
+	})
+}
+
+func cutPoolMember(actorID string) (string, string, bool) {
+	index := strings.LastIndex(actorID, poolMemberSeparator)
+	if index < 0 {
+		return "", "", false
+	}
+
+	return actorID[:index], actorID[index+1:], true
+}
+
+// PoolID 路由池id
+func (p *Pool) PoolID() string {
+	return p.poolID
+}
+
+// Size 成员数量
+func (p *Pool) Size() int {
+	p.RLock()
+	defer p.RUnlock()
+
+	return len(p.members)
+}
+
+// Members 成员actorID列表
+func (p *Pool) Members() []string {
+	p.RLock()
+	defer p.RUnlock()
+
+	members := make([]string, len(p.members))
+	copy(members, p.members)
+
+	return members
+}
+
+// Resize 调整成员数量,缩容时停止多余的成员
+func (p *Pool) Resize(size int) {
+	if size < 1 {
+		size = 1
+	}
+
+	p.Lock()
+	if p.stopped {
+		p.Unlock()
+		return
+	}
+
+	var removed []string
+	for len(p.members) < size {
+		memberID := p.poolID + poolMemberSeparator + strconv.Itoa(len(p.members))
+		p.members = append(p.members, memberID)
+		p.ring.Add(memberID)
+	}
+
+	for len(p.members) > size {
+		memberID := p.members[len(p.members)-1]
+		p.members = p.members[:len(p.members)-1]
+		p.ring.Remove(memberID)
+		removed = append(removed, memberID)
+	}
+
+	members := p.members
+	p.Unlock()
+
+	for _, memberID := range members {
+		p.ensure(memberID)
+	}
+
+	for _, memberID := range removed {
+		if thisActor, found := p.system.GetActor(memberID); found {
+			thisActor.Exit()
+		}
+	}
+}
+
+// Stop 停止路由池及所有成员
+func (p *Pool) Stop() {
+	p.markStopped()
+	p.system.routers.pools.Delete(p.poolID)
+
+	for _, memberID := range p.Members() {
+		if thisActor, found := p.system.GetActor(memberID); found {
+			thisActor.Exit()
+		}
+	}
+}
+
+func (p *Pool) markStopped() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.stopped = true
+}
+
+func (p *Pool) isMember(memberID string) bool {
+	for _, id := range p.members {
+		if id == memberID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ensure 成员actor不存在时创建
+func (p *Pool) ensure(memberID string) (*Actor, bool) {
+	if thisActor, found := p.system.GetActor(memberID); found && thisActor.State() != StopState {
+		return thisActor, true
+	}
+
+	handler := p.factory(memberID)
+	if handler == nil {
+		return nil, false
+	}
+
+	iActor, err := p.system.createActor(memberID, handler, p.factory)
+	if err != nil {
+		clog.Warnf("[Pool] Create member fail. [poolID = %s, memberID = %s, err = %v]", p.poolID, memberID, err)
+		return nil, false
+	}
+
+	thisActor, ok := iActor.(*Actor)
+	return thisActor, ok
+}
+
+// supervise 成员停止后,如果仍属于路由池则重新创建
+func (p *Pool) supervise(memberID string) {
+	p.RLock()
+	restart := !p.stopped && p.isMember(memberID)
+	p.RUnlock()
+
+	if restart {
+		p.ensure(memberID)
+	}
+}
+
+func (p *Pool) route(m *cfacade.Message) (*Actor, bool) {
+	p.RLock()
+	if len(p.members) < 1 {
+		p.RUnlock()
+		return nil, false
+	}
+
+	var memberID string
+
+	switch p.strategy {
+	case LeastLoadedRouter:
+		memberID = p.leastLoaded()
+	case HashRouter:
+		memberID, _ = p.ring.Get(p.hashKey(m))
+	default:
+		index := atomic.AddUint64(&p.counter, 1) % uint64(len(p.members))
+		memberID = p.members[index]
+	}
+	p.RUnlock()
+
+	return p.ensure(memberID)
+}
+
+func (p *Pool) leastLoaded() string {
+	var (
+		memberID string
+		minCount int32 = -1
+	)
+
+	for _, id := range p.members {
+		thisActor, found := p.system.GetActor(id)
+		if !found {
+			continue
+		}
+
+		count := thisActor.localMail.Count() + thisActor.remoteMail.Count()
+		if minCount < 0 || count < minCount {
+			memberID, minCount = id, count
+		}
+	}
+
+	if memberID == "" {
+		return p.members[0]
+	}
+
+	return memberID
+}
+
+// CreatePool 创建路由池,发送给poolID的Call/CallWait将按strategy转发给成员actor
+// factory 根据成员actorID创建handler, hashKey 为HashRouter获取消息key的函数(默认为session uid或来源path)
+func (p *System) CreatePool(poolID string, size int, factory ActorFactory, strategy RouterStrategy, hashKey ...RouterHashKey) (*Pool, error) {
+	if poolID == "" || factory == nil {
+		return nil, ErrActorIDIsNil
+	}
+
+	if _, found := p.GetActor(poolID); found {
+		return nil, cerror.Errorf("Actor id already exists. [poolID = %s]", poolID)
+	}
+
+	pool := &Pool{
+		system:   p,
+		poolID:   poolID,
+		factory:  factory,
+		strategy: strategy,
+		hashKey:  defaultHashKey,
+		ring:     csharding.NewRing(csharding.DefaultReplicas),
+	}
+
+	if len(hashKey) > 0 && hashKey[0] != nil {
+		pool.hashKey = hashKey[0]
+	}
+
+	if _, loaded := p.routers.pools.LoadOrStore(poolID, pool); loaded {
+		return nil, cerror.Errorf("Pool already exists. [poolID = %s]", poolID)
+	}
+
+	pool.Resize(size)
+
+	return pool, nil
+}
+
+// GetPool 获取路由池
+func (p *System) GetPool(poolID string) (*Pool, bool) {
+	return p.routers.get(poolID)
+}
@@ -0,0 +1,116 @@
+package cherryActor
+
+import (
+	"testing"
+	"time"
+
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+type testWorkerActor struct {
+	Base
+	handled chan string
+}
+
+func (p *testWorkerActor) OnInit() {
+	p.Remote().Register("work", func() {
+		p.handled <- p.ActorID()
+	})
+}
+
+func newTestPool(t *testing.T, strategy RouterStrategy, hashKey ...RouterHashKey) (*System, *Pool, chan string) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+
+	handled := make(chan string, 16)
+	pool, err := system.CreatePool("battle", 3, func(_ string) cfacade.IActorHandler {
+		return &testWorkerActor{handled: handled}
+	}, strategy, hashKey...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return system, pool, handled
+}
+
+func waitHandled(t *testing.T, handled chan string) string {
+	select {
+	case memberID := <-handled:
+		return memberID
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+	return ""
+}
+
+func TestPoolRoundRobin(t *testing.T) {
+	system, _, handled := newTestPool(t, RoundRobinRouter)
+
+	members := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		system.Call(".caller", ".battle", "work", nil)
+		members[waitHandled(t, handled)] = true
+	}
+
+	if len(members) != 3 {
+		t.Fatalf("members = %v", members)
+	}
+}
+
+func TestPoolHash(t *testing.T) {
+	system, _, handled := newTestPool(t, HashRouter, func(m *cfacade.Message) string {
+		return "room-1"
+	})
+
+	system.Call(".caller", ".battle", "work", nil)
+	first := waitHandled(t, handled)
+
+	for i := 0; i < 3; i++ {
+		system.Call(".caller", ".battle", "work", nil)
+		if memberID := waitHandled(t, handled); memberID != first {
+			t.Fatalf("memberID = %s, first = %s", memberID, first)
+		}
+	}
+}
+
+func TestPoolResizeAndSupervise(t *testing.T) {
+	system, pool, _ := newTestPool(t, LeastLoadedRouter)
+
+	pool.Resize(1)
+	if pool.Size() != 1 || pool.Members()[0] != "battle#0" {
+		t.Fatalf("members = %v", pool.Members())
+	}
+
+	member, _ := system.GetActor("battle#0")
+	member.Exit()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if restarted, found := system.GetActor("battle#0"); found && restarted != member {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("member not restarted")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPoolRouteWithStats(t *testing.T) {
+	system, _, handled := newTestPool(t, RoundRobinRouter)
+
+	// 路由时读取成员状态,与introspect并发执行(go test -race)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10; i++ {
+			system.Stats("battle", false)
+		}
+	}()
+
+	for i := 0; i < 6; i++ {
+		system.Call(".caller", ".battle", "work", nil)
+		waitHandled(t, handled)
+	}
+	<-done
+}
@@ -0,0 +1,299 @@
+package cherryActor
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cutils "github.com/cherry-game/cherry/extend/utils"
+	clog "github.com/cherry-game/cherry/logger"
+)
+
+var (
+	GoroutineMode SchedulerMode = 0 // 每个actor运行在独立的goroutine中
+	PoolMode      SchedulerMode = 1 // actor有消息时调度到固定数量的worker中执行(同一actor的消息仍串行处理)
+)
+
+const (
+	defaultThroughput    = 100 // 每次调度最多处理的消息数,之后让出worker
+	pooledCallbackBuffer = 16  // PoolMode下actor的callback缓冲区大小
+)
+
+type (
+	SchedulerMode int
+
+	// ISchedulerMode actor handler实现该接口,可单独指定该actor的调度方式(否则使用System的默认调度方式)
+	ISchedulerMode interface {
+		SchedulerMode() SchedulerMode
+	}
+
+	// scheduler PoolMode actor的调度器
+	//
+	// actor的队列、callback或close有新数据时将actor放入runQueue(已在runQueue或正在执行则忽略),
+	// worker每次从runQueue取出一个actor,处理最多throughput条消息.
+	// 注意:在PoolMode的actor中CallWait或阻塞等待其他PoolMode的actor,会占用worker,
+	// worker全部阻塞时将导致死锁,应使用CallAsync
+	scheduler struct {
+		sync.Mutex
+		cond       *sync.Cond
+		runQueue   []*Actor
+		workers    int // worker数量
+		throughput int // 每次调度最多处理的消息数
+		started    bool
+		stopped    bool
+		wg         sync.WaitGroup
+	}
+)
+
+func newScheduler() *scheduler {
+	s := &scheduler{
+		workers:    runtime.NumCPU(),
+		throughput: defaultThroughput,
+	}
+	s.cond = sync.NewCond(&s.Mutex)
+
+	return s
+}
+
+func (s *scheduler) set(workers, throughput int) {
+	s.Lock()
+	defer s.Unlock()
+
+	if workers > 0 && !s.started {
+		s.workers = workers
+	}
+
+	if throughput > 0 {
+		s.throughput = throughput
+	}
+}
+
+// push 将actor放入runQueue,第一次调用时启动worker
+func (s *scheduler) push(thisActor *Actor) {
+	s.Lock()
+	if !s.started {
+		s.started = true
+		s.wg.Add(s.workers)
+		for i := 0; i < s.workers; i++ {
+			go s.work()
+		}
+	}
+
+	s.runQueue = append(s.runQueue, thisActor)
+	s.Unlock()
+
+	s.cond.Signal()
+}
+
+func (s *scheduler) pop() (*Actor, int, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	for len(s.runQueue) < 1 && !s.stopped {
+		s.cond.Wait()
+	}
+
+	if len(s.runQueue) < 1 {
+		return nil, 0, false
+	}
+
+	thisActor := s.runQueue[0]
+	s.runQueue[0] = nil
+	s.runQueue = s.runQueue[1:]
+
+	return thisActor, s.throughput, true
+}
+
+func (s *scheduler) work() {
+	defer s.wg.Done()
+
+	for {
+		thisActor, throughput, ok := s.pop()
+		if !ok {
+			return
+		}
+
+		thisActor.runSlice(throughput)
+	}
+}
+
+// stop 停止所有worker(所有PoolMode actor停止后调用)
+// wait为false时不等待正在执行的worker(有actor停止超时,worker可能一直阻塞)
+func (s *scheduler) stop(wait bool) {
+	s.Lock()
+	s.stopped = true
+	s.Unlock()
+
+	s.cond.Broadcast()
+	if wait {
+		s.wg.Wait()
+	}
+}
+
+// schedulerMode 获取actor的调度方式
+func (p *System) schedulerModeOf(handler interface{}) SchedulerMode {
+	if modeHandler, ok := handler.(ISchedulerMode); ok {
+		return modeHandler.SchedulerMode()
+	}
+
+	return p.schedulerMode
+}
+
+// start 启动actor
+func (p *Actor) start() {
+	if p.pooled {
+		p.schedule()
+		return
+	}
+
+	go p.run()
+}
+
+// schedule PoolMode的actor有待处理的数据时放入runQueue
+func (p *Actor) schedule() {
+	if atomic.CompareAndSwapInt32(&p.scheduled, 0, 1) {
+		p.system.scheduler.push(p)
+	}
+}
+
+// signal 提交callback或close后,唤醒PoolMode的actor
+func (p *Actor) signal() {
+	if p.pooled {
+		p.schedule()
+	}
+}
+
+// pushCallback 提交在actor goroutine中执行的函数,callback队列已满时阻塞
+func (p *Actor) pushCallback(fn func()) {
+	p.callback <- fn
+	p.signal()
+}
+
+// offerCallback 提交在actor goroutine中执行的函数,callback队列已满时返回false
+func (p *Actor) offerCallback(fn func()) bool {
+	select {
+	case p.callback <- fn:
+		p.signal()
+		return true
+	default:
+		return false
+	}
+}
+
+// runSlice 在worker中执行,处理最多throughput条消息
+func (p *Actor) runSlice(throughput int) {
+	if !p.started {
+		p.started = true
+		p.onInit()
+	}
+
+	for i := 0; i < throughput; i++ {
+		processed, exit := p.poll()
+		if exit {
+			// scheduled保持为1,停止后不再被调度.
+			// 等待子actor停止时不占用worker,避免worker全部阻塞后子actor无法被调度执行
+			if p.path.IsParent() && p.child.hasChildren() {
+				go p.onStop()
+			} else {
+				p.onStop()
+			}
+			return
+		}
+
+		if !processed {
+			break
+		}
+	}
+
+	atomic.StoreInt32(&p.scheduled, 0)
+
+	// 清除调度标记前提交的数据可能未被处理(重启退避期间只响应close)
+	if p.pending() && (p.restartWait() <= 0 || len(p.close) > 0) {
+		p.schedule()
+	}
+}
+
+// poll 非阻塞处理一条消息
+func (p *Actor) poll() (processed bool, exit bool) {
+	select {
+	case <-p.close:
+		p.setState(StopState)
+	default:
+	}
+
+	if p.restartPending {
+		if p.State() == StopState {
+			// 重启前actor已停止,不再重启,未处理的消息转为死信
+			p.restartPending = false
+			p.stopOnFailure()
+		} else if wait := p.restartWait(); wait > 0 {
+			// 退避期间不占用worker,到期后重新调度
+			if atomic.CompareAndSwapInt32(&p.restartTimer, 0, 1) {
+				time.AfterFunc(wait, func() {
+					atomic.StoreInt32(&p.restartTimer, 0)
+					p.schedule()
+				})
+			}
+			return false, false
+		} else {
+			p.restartPending = false
+			p.restart()
+			return true, false
+		}
+	}
+
+	if p.State() == StopState {
+		if p.isDiscard() {
+			p.discardMessages()
+			return false, true
+		}
+
+		if !p.pending() {
+			return false, true
+		}
+	}
+
+	if p.priority.Count() > 0 {
+		p.processPriority()
+		return true, false
+	}
+
+	if len(p.stash.unstashed) > 0 && p.State() == WorkerState {
+		p.processUnstashed()
+		return true, false
+	}
+
+	select {
+	case <-p.localMail.C:
+		p.processLocal()
+	case <-p.remoteMail.C:
+		p.processRemote()
+	case <-p.event.C:
+		p.processEvent()
+	case cb := <-p.callback:
+		if cb != nil {
+			cutils.Try(cb, func(errString string) {
+				clog.Error(errString)
+			})
+		}
+	case <-p.close:
+		p.setState(StopState)
+	default:
+		return p.State() == StopState, false
+	}
+
+	return true, false
+}
+
+// pending 是否有待处理的数据
+func (p *Actor) pending() bool {
+	return p.restartPending ||
+		p.priority.Count() > 0 ||
+		p.localMail.Count() > 0 ||
+		p.remoteMail.Count() > 0 ||
+		p.event.Count() > 0 ||
+		len(p.callback) > 0 ||
+		len(p.close) > 0 ||
+		(len(p.stash.unstashed) > 0 && p.State() == WorkerState)
+}
@@ -0,0 +1,190 @@
+package cherryActor
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testPooledActor struct {
+	Base
+	running int32
+	overlap int32
+	count   int32
+	total   int32
+	done    chan struct{}
+}
+
+func (p *testPooledActor) SchedulerMode() SchedulerMode {
+	return PoolMode
+}
+
+func (p *testPooledActor) OnInit() {
+	p.Remote().Register("add", p.add)
+}
+
+func (p *testPooledActor) add() {
+	if !atomic.CompareAndSwapInt32(&p.running, 0, 1) {
+		atomic.StoreInt32(&p.overlap, 1)
+	}
+	runtime.Gosched()
+	atomic.StoreInt32(&p.running, 0)
+
+	if atomic.AddInt32(&p.count, 1) == p.total {
+		close(p.done)
+	}
+}
+
+// waitInit 等待actor执行完OnInit(callback在OnInit之后执行)
+func waitInit(t *testing.T, thisActor *Actor) {
+	inited := make(chan struct{})
+	thisActor.Go(func() {}, func() {
+		close(inited)
+	})
+
+	select {
+	case <-inited:
+	case <-time.After(time.Second):
+		t.Fatalf("actor init timeout. path = %s", thisActor.path)
+	}
+}
+
+func TestPoolModeSerial(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+	system.SetSchedulerPool(4, 8)
+	defer system.Stop()
+
+	const senders, perSender = 8, 200
+
+	handler := &testPooledActor{total: senders * perSender, done: make(chan struct{})}
+	thisActor, err := system.CreateActor("pooled", handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !thisActor.(*Actor).pooled {
+		t.Fatal("actor is not pooled")
+	}
+	waitInit(t, thisActor.(*Actor))
+
+	var wg sync.WaitGroup
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perSender; j++ {
+				system.Call(".caller", ".pooled", "add", nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case <-handler.done:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("count = %d", atomic.LoadInt32(&handler.count))
+	}
+
+	if atomic.LoadInt32(&handler.overlap) != 0 {
+		t.Fatal("messages of one actor processed concurrently")
+	}
+}
+
+func TestPoolModeChild(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+	system.SetSchedulerMode(PoolMode)
+	defer system.Stop()
+
+	parent, _ := system.CreateActor("parent", &testActor{})
+
+	handler := &testPooledActor{total: 1, done: make(chan struct{})}
+	child, err := parent.(*Actor).Child().Create("child", handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !parent.(*Actor).pooled || !child.(*Actor).pooled {
+		t.Fatal("actor is not pooled")
+	}
+	waitInit(t, child.(*Actor))
+
+	system.Call(".caller", ".parent.child", "add", nil)
+
+	select {
+	case <-handler.done:
+	case <-time.After(time.Second):
+		t.Fatal("child message timeout")
+	}
+
+	child.Exit()
+
+	select {
+	case <-child.(*Actor).stopped:
+	case <-time.After(time.Second):
+		t.Fatal("child stop timeout")
+	}
+}
+
+type benchActor struct {
+	Base
+	wg *sync.WaitGroup
+}
+
+func (p *benchActor) OnInit() {
+	p.Remote().Register("inc", p.inc)
+}
+
+func (p *benchActor) inc() {
+	p.wg.Done()
+}
+
+func benchmarkScheduler(b *testing.B, mode SchedulerMode) {
+	for _, actorNum := range []int{100, 10000} {
+		b.Run(fmt.Sprintf("actors=%d", actorNum), func(b *testing.B) {
+			system := NewSystem()
+			system.SetRemoteInvoke(directRemoteInvoke)
+			system.SetSchedulerMode(mode)
+			defer system.Stop()
+
+			var before, after runtime.MemStats
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+
+			wg := &sync.WaitGroup{}
+			targets := make([]string, actorNum)
+			for i := 0; i < actorNum; i++ {
+				actorID := fmt.Sprintf("bench%d", i)
+				system.CreateActor(actorID, &benchActor{wg: wg})
+				targets[i] = "." + actorID
+			}
+
+			runtime.ReadMemStats(&after)
+			memPerActor := float64(after.HeapInuse+after.StackInuse-before.HeapInuse-before.StackInuse) / float64(actorNum)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			wg.Add(b.N)
+			for i := 0; i < b.N; i++ {
+				system.Call(".bench", targets[i%actorNum], "inc", nil)
+			}
+			wg.Wait()
+
+			// ResetTimer会清除自定义指标,需在计时结束后上报
+			b.ReportMetric(memPerActor, "mem-B/actor")
+		})
+	}
+}
+
+func BenchmarkGoroutineMode(b *testing.B) {
+	benchmarkScheduler(b, GoroutineMode)
+}
+
+func BenchmarkPoolMode(b *testing.B) {
+	benchmarkScheduler(b, PoolMode)
+}
@@ -0,0 +1,157 @@
+package cherryActor
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	cutils "github.com/cherry-game/cherry/extend/utils"
+)
+
+const (
+	defaultStopTimeout = 30 * time.Second
+)
+
+// 两阶段停止:
+// 1. system标记为stopping,不再激活/路由新的actor,发送给停止中actor的消息返回ActorStopping
+// 2. 按停止顺序分组停止顶层actor,每个actor处理完邮箱中的消息后,先停止子actor再执行自身的OnStop()
+// 超过截止时间仍未停止的actor将被放弃等待并强制退出(不再接收消息),并输出报告
+
+// exit 通知actor退出,重复调用不会阻塞
+func (p *Actor) exit() {
+	cutils.Try(func() {
+		select {
+		case p.close <- struct{}{}:
+			p.signal()
+		default:
+		}
+	}, func(errString string) {
+		// actor已停止,close channel已关闭
+	})
+}
+
+// forceStop 停止超时的actor不再接收消息,阻塞的函数返回后,队列中未处理的消息转为死信
+func (p *Actor) forceStop() {
+	atomic.AddInt32(&p.system.forceStopped, 1)
+	p.setState(StopState)
+	atomic.StoreInt32(&p.discard, 1)
+	p.exit()
+
+	if p.path.IsParent() {
+		p.system.removeActor(p)
+		p.system.location.unregister(p.ActorID())
+	} else if parent, found := p.system.GetActor(p.path.ActorID); found {
+		parent.child.Remove(p.path.ChildID)
+	}
+}
+
+// report 停止超时时的actor状态
+func (p *Actor) report() string {
+	invoking, _ := p.invoking.Load().(string)
+
+	return fmt.Sprintf("path = %s, state = %d, local = %d, remote = %d, event = %d, invoking = %s, lastAt = %d",
+		p.path,
+		p.State(),
+		p.localMail.Count(),
+		p.remoteMail.Count(),
+		p.event.Count(),
+		invoking,
+		p.LastAt(),
+	)
+}
+
+// stopDeadline 停止的截止时间,stopTimeout <= 0 时一直等待
+func (p *System) stopDeadline() time.Time {
+	if p.stopTimeout <= 0 {
+		return time.Time{}
+	}
+
+	return time.Now().Add(p.stopTimeout)
+}
+
+// waitActors 等待actor停止,返回截止时间后仍未停止的actor
+func (p *System) waitActors(actors []*Actor, deadline time.Time) []*Actor {
+	var (
+		stuck   []*Actor
+		timeout <-chan time.Time
+	)
+
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for i, thisActor := range actors {
+		select {
+		case <-thisActor.stopped:
+		case <-timeout:
+			for _, a := range actors[i:] {
+				select {
+				case <-a.stopped:
+				default:
+					stuck = append(stuck, a)
+				}
+			}
+			return stuck
+		}
+	}
+
+	return stuck
+}
+
+// stopGroups 按停止顺序将顶层actor分组,匹配stopOrder前缀的actor按顺序先停止,其余actor最后停止
+func (p *System) stopGroups() [][]*Actor {
+	groups := make([][]*Actor, len(p.stopOrder)+1)
+
+	p.actorMap.Range(func(key, value any) bool {
+		thisActor, ok := value.(*Actor)
+		if !ok {
+			return true
+		}
+
+		index := len(p.stopOrder)
+		for i, prefix := range p.stopOrder {
+			if strings.HasPrefix(thisActor.ActorID(), prefix) {
+				index = i
+				break
+			}
+		}
+
+		groups[index] = append(groups[index], thisActor)
+		return true
+	})
+
+	return groups
+}
+
+func (p *System) isStopping() bool {
+	return atomic.LoadInt32(&p.stopping) == 1
+}
+
+// drain 分组停止所有顶层actor,返回截止时间后仍未停止的actor
+func (p *System) drain() []*Actor {
+	var stuck []*Actor
+
+	for _, group := range p.stopGroups() {
+		for _, thisActor := range group {
+			thisActor.exit()
+		}
+
+		stuck = append(stuck, p.waitActors(group, p.stopAt)...)
+	}
+
+	return stuck
+}
+
+// SetStopTimeout 设置停止的截止时间(默认30秒), <= 0 则一直等待所有actor停止
+func (p *System) SetStopTimeout(d time.Duration) {
+	p.stopTimeout = d
+}
+
+// SetStopOrder 设置顶层actor的停止顺序(actorID前缀),
+// 匹配的actor按前缀顺序分组依次停止,未匹配的actor在最后停止
+func (p *System) SetStopOrder(prefixes ...string) {
+	p.stopOrder = prefixes
+}
@@ -0,0 +1,219 @@
+package cherryActor
+
+import (
+	"testing"
+	"time"
+)
+
+type testStopActor struct {
+	Base
+	name    string
+	stopped chan string
+	child   *testStopActor
+}
+
+func (p *testStopActor) OnInit() {
+	if p.child != nil {
+		p.Child().Create(p.child.name, p.child)
+	}
+
+	p.Remote().Register("block", func() {
+		select {}
+	})
+}
+
+func (p *testStopActor) OnStop() {
+	p.stopped <- p.name
+}
+
+type testReleaseActor struct {
+	Base
+	release chan struct{}
+}
+
+func (p *testReleaseActor) OnStop() {
+	<-p.release
+}
+
+func TestStopOrder(t *testing.T) {
+	system := NewSystem()
+	system.SetStopOrder("player")
+
+	stopped := make(chan string, 4)
+	child := &testStopActor{name: "bag", stopped: stopped}
+	system.CreateActor("player1", &testStopActor{name: "player1", stopped: stopped, child: child})
+	system.CreateActor("db", &testStopActor{name: "db", stopped: stopped})
+	time.Sleep(10 * time.Millisecond)
+
+	system.Stop()
+	close(stopped)
+
+	var order []string
+	for name := range stopped {
+		order = append(order, name)
+	}
+
+	if len(order) != 3 || order[0] != "bag" || order[1] != "player1" || order[2] != "db" {
+		t.Fatalf("order = %v", order)
+	}
+}
+
+func TestStopTimeout(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+	system.SetStopTimeout(50 * time.Millisecond)
+
+	system.CreateActor("stuck", &testStopActor{name: "stuck", stopped: make(chan string, 1)})
+	system.Call(".caller", ".stuck", "block", nil)
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		system.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stop is blocked by stuck actor")
+	}
+
+	if code := system.Call(".caller", ".other", "work", nil); code == 0 {
+		t.Fatal("stopping system accept new message")
+	}
+
+	if _, found := system.GetActor("stuck"); found {
+		t.Fatal("stuck actor is not forced to exit")
+	}
+}
+
+func TestStopRejectClusterMessage(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+	system.SetStopOrder("player")
+
+	release := make(chan struct{})
+	system.CreateActor("player1", &testReleaseActor{release: release})
+	db, _ := system.CreateActor("db", &testActor{})
+	waitInit(t, db.(*Actor))
+
+	done := make(chan struct{})
+	go func() {
+		system.Stop()
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// player停止期间,db不再接收其他节点的消息,节点内的消息仍可投递
+	m := newTestMessage("save")
+	m.Target = ".db"
+	m.IsCluster = true
+	if system.PostRemote(m) {
+		t.Fatal("later stop group accept cluster message")
+	}
+
+	if code := system.Call(".player1", ".db", "save", nil); code != 0 {
+		t.Fatalf("code = %d", code)
+	}
+
+	close(release)
+	<-done
+}
+
+func TestPassivateNotWaitChild(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+
+	stopped := make(chan string, 2)
+	child := &testStopActor{name: "bag", stopped: stopped}
+	parent, _ := system.CreateActor("player1", &testStopActor{name: "player1", stopped: stopped, child: child})
+	waitInit(t, parent.(*Actor))
+
+	system.Call(".caller", ".player1.bag", "block", nil)
+	time.Sleep(10 * time.Millisecond)
+
+	// 非system停止时,父actor不等待阻塞的子actor
+	parent.Exit()
+	select {
+	case <-parent.(*Actor).stopped:
+	case <-time.After(time.Second):
+		t.Fatal("parent is blocked by child")
+	}
+}
+
+type testPooledStopActor struct {
+	testStopActor
+}
+
+func (p *testPooledStopActor) SchedulerMode() SchedulerMode {
+	return PoolMode
+}
+
+func TestStopPoolModeWaitChildren(t *testing.T) {
+	system := NewSystem()
+	system.SetSchedulerPool(1, 8)
+	system.SetStopTimeout(5 * time.Second)
+
+	stopped := make(chan string, 2)
+	child := &testPooledStopActor{testStopActor{name: "bag", stopped: stopped}}
+	parent := &testPooledStopActor{testStopActor{name: "player1", stopped: stopped}}
+	parentActor, _ := system.CreateActor("player1", parent)
+	waitInit(t, parentActor.(*Actor))
+	parentActor.(*Actor).Child().Create("bag", child)
+
+	// 只有一个worker时,父actor等待子actor停止不能占用worker
+	begin := time.Now()
+	system.Stop()
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Fatalf("stop elapsed = %v", elapsed)
+	}
+
+	close(stopped)
+	var order []string
+	for name := range stopped {
+		order = append(order, name)
+	}
+
+	if len(order) != 2 || order[0] != "bag" || order[1] != "player1" {
+		t.Fatalf("order = %v", order)
+	}
+}
+
+func TestStopChildSharedDeadline(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+	system.SetStopTimeout(200 * time.Millisecond)
+	system.SetStopOrder("db", "player")
+
+	release := make(chan struct{})
+	system.CreateActor("db", &testReleaseActor{release: release})
+
+	stopped := make(chan string, 2)
+	child := &testStopActor{name: "bag", stopped: stopped}
+	parent, _ := system.CreateActor("player1", &testStopActor{name: "player1", stopped: stopped, child: child})
+	waitInit(t, parent.(*Actor))
+	system.Call(".caller", ".player1.bag", "block", nil)
+
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		close(release)
+	}()
+
+	// 子actor与system使用同一个截止时间,不在父actor开始停止时重新计算
+	begin := time.Now()
+	system.Stop()
+
+	select {
+	case name := <-stopped:
+		if name != "player1" {
+			t.Fatalf("name = %s", name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("parent not stopped")
+	}
+
+	if elapsed := time.Since(begin); elapsed > 300*time.Millisecond {
+		t.Fatalf("parent stopped after %v", elapsed)
+	}
+}
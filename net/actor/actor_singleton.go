@@ -0,0 +1,177 @@
+package cherryActor
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	ccode "github.com/cherry-game/cherry/code"
+	cfacade "github.com/cherry-game/cherry/facade"
+	clog "github.com/cherry-game/cherry/logger"
+)
+
+const (
+	SingletonPrefix = "@" // 单例actor逻辑路径的节点前缀. 逻辑路径 = @nodeType.actorID
+)
+
+type (
+	// SingletonFactory 创建单例actor handler
+	SingletonFactory func() cfacade.IActorHandler
+
+	// singletons 集群单例actor
+	//
+	// 同类型的所有节点都注册单例actor,按nodeID排序后第一个节点为owner,只在owner节点创建actor.
+	// owner节点离开后,由新的owner节点自动创建
+	singletons struct {
+		sync.Mutex
+		system    *System
+		factories map[string]SingletonFactory // key:actorID, value:factory
+		exiting   map[string]*Actor           // key:actorID, value:正在停止的单例actor
+	}
+)
+
+func newSingletons(system *System) *singletons {
+	return &singletons{
+		system:    system,
+		factories: make(map[string]SingletonFactory),
+		exiting:   make(map[string]*Actor),
+	}
+}
+
+// NewSingletonPath 创建单例actor的逻辑路径,调用时自动解析为owner节点的actor路径
+func NewSingletonPath(nodeType, actorID string) string {
+	return cfacade.NewPath(SingletonPrefix+nodeType, actorID)
+}
+
+// actorFactory 转换为ActorFactory,重启时重新创建handler
+func (f SingletonFactory) actorFactory() ActorFactory {
+	return func(_ string) cfacade.IActorHandler {
+		return f()
+	}
+}
+
+func (p *singletons) register(actorID string, factory SingletonFactory) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.factories[actorID] = factory
+}
+
+func (p *singletons) onAfterInit() {
+	if len(p.factories) < 1 {
+		return
+	}
+
+	if discovery := p.system.app.Discovery(); discovery != nil {
+		listener := func(member cfacade.IMember) {
+			if member.GetNodeType() == p.system.app.NodeType() {
+				p.elect()
+			}
+		}
+
+		discovery.OnAddMember(listener)
+		discovery.OnRemoveMember(listener)
+	}
+
+	p.elect()
+}
+
+// owner 获取节点类型的owner节点id
+func (p *singletons) owner(nodeType string) (string, bool) {
+	discovery := p.system.app.Discovery()
+	if discovery == nil {
+		if nodeType == p.system.app.NodeType() {
+			return p.system.NodeID(), true
+		}
+		return "", false
+	}
+
+	memberList := discovery.ListByType(nodeType)
+	if len(memberList) < 1 {
+		return "", false
+	}
+
+	sort.Slice(memberList, func(i, j int) bool {
+		return memberList[i].GetNodeID() < memberList[j].GetNodeID()
+	})
+
+	return memberList[0].GetNodeID(), true
+}
+
+// elect 根据当前的owner节点,创建或停止本节点的单例actor
+func (p *singletons) elect() {
+	if p.system.isStopping() {
+		return
+	}
+
+	p.Lock()
+
+	ownerID, _ := p.owner(p.system.app.NodeType())
+	isOwner := ownerID == p.system.NodeID()
+
+	var exits []*Actor
+	for actorID, factory := range p.factories {
+		// 正在停止的actor,停止完成后重新选举,避免同时存在两个实例
+		if _, exiting := p.exiting[actorID]; exiting {
+			continue
+		}
+
+		thisActor, found := p.system.GetActor(actorID)
+
+		if isOwner && !found {
+			if _, err := p.system.createActor(actorID, factory(), factory.actorFactory()); err != nil {
+				clog.Warnf("[singleton] Create actor fail. [actorID = %s, err = %v]", actorID, err)
+				continue
+			}
+			clog.Infof("[singleton] Actor is created. [actorID = %s, nodeID = %s]", actorID, ownerID)
+		}
+
+		if !isOwner && found {
+			p.exiting[actorID] = thisActor
+			exits = append(exits, thisActor)
+		}
+	}
+
+	p.Unlock()
+
+	// Exit可能阻塞,在锁外执行
+	for _, thisActor := range exits {
+		thisActor.Exit()
+		clog.Infof("[singleton] Actor moved to owner node. [actorID = %s, owner = %s]", thisActor.ActorID(), ownerID)
+
+		go p.onExited(thisActor)
+	}
+}
+
+// onExited 等待单例actor停止后重新选举(停止期间本节点可能重新成为owner)
+func (p *singletons) onExited(thisActor *Actor) {
+	<-thisActor.stopped
+
+	p.Lock()
+	if p.exiting[thisActor.ActorID()] == thisActor {
+		delete(p.exiting, thisActor.ActorID())
+	}
+	p.Unlock()
+
+	p.elect()
+}
+
+// resolve 将单例actor的逻辑路径解析为owner节点的actor路径
+func (p *singletons) resolve(target string) (string, int32) {
+	if !strings.HasPrefix(target, SingletonPrefix) {
+		return target, ccode.OK
+	}
+
+	targetPath, err := cfacade.ToActorPath(target)
+	if err != nil {
+		return target, ccode.ActorConvertPathError
+	}
+
+	ownerID, found := p.owner(strings.TrimPrefix(targetPath.NodeID, SingletonPrefix))
+	if !found {
+		clog.Warnf("[singleton] Owner node not found. [target = %s]", target)
+		return target, ccode.DiscoveryNotFoundNode
+	}
+
+	return cfacade.NewChildPath(ownerID, targetPath.ActorID, targetPath.ChildID), ccode.OK
+}
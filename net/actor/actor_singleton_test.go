@@ -0,0 +1,20 @@
+package cherryActor
+
+import (
+	"testing"
+
+	ccode "github.com/cherry-game/cherry/code"
+)
+
+func TestSingletonPath(t *testing.T) {
+	path := NewSingletonPath("center", "leaderboard")
+	if path != "@center.leaderboard" {
+		t.Fatalf("path = %s", path)
+	}
+
+	s := newSingletons(nil)
+	target, code := s.resolve("game-1.player")
+	if ccode.IsFail(code) || target != "game-1.player" {
+		t.Fatalf("target = %s, code = %d", target, code)
+	}
+}
@@ -0,0 +1,222 @@
+package cherryActor
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	cconst "github.com/cherry-game/cherry/const"
+	ctimeWheel "github.com/cherry-game/cherry/extend/time_wheel"
+	cutils "github.com/cherry-game/cherry/extend/utils"
+	clog "github.com/cherry-game/cherry/logger"
+)
+
+const (
+	DefaultSnapshotDir   = "./snapshot" // 默认的快照文件目录
+	snapshotFileExt      = ".snapshot"
+	minSnapshotInterval  = time.Second
+	snapshotFileFileMode = 0644
+)
+
+type (
+	// snapshots actor状态快照
+	//
+	// handler实现IPersistent接口的actor,创建时从store恢复状态,
+	// 定时及停止时将状态保存到store.快照的生成及保存都在actor goroutine中执行
+	snapshots struct {
+		sync.RWMutex
+		system   *System
+		store    ISnapshotStore    // 快照存储
+		interval time.Duration     // 定时保存间隔(<=0则只在停止时保存)
+		timer    *ctimeWheel.Timer // 定时保存的定时器
+	}
+
+	// FileStore 基于文件的快照存储,每个actor一个文件
+	FileStore struct {
+		dir string
+	}
+)
+
+func newSnapshots(system *System) *snapshots {
+	return &snapshots{
+		system: system,
+	}
+}
+
+func (p *snapshots) set(store ISnapshotStore, interval time.Duration) {
+	p.stop()
+
+	p.Lock()
+	p.store = store
+	p.interval = interval
+	p.Unlock()
+
+	if store == nil || interval <= 0 {
+		return
+	}
+
+	if interval < minSnapshotInterval {
+		interval = minSnapshotInterval
+	}
+
+	p.timer = globalTimer.BuildEveryFunc(interval, p.tick, true)
+}
+
+func (p *snapshots) getStore() ISnapshotStore {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.store
+}
+
+// tick 通知所有持久化actor保存快照
+func (p *snapshots) tick() {
+	p.system.eachActor(func(thisActor *Actor) {
+		if thisActor.State() != WorkerState {
+			return
+		}
+
+		if _, ok := thisActor.handler.(IPersistent); !ok {
+			return
+		}
+
+		if !thisActor.offerCallback(thisActor.saveSnapshot) {
+			clog.Warnf("[snapshot] Callback channel is full. [path = %s]", thisActor.path)
+		}
+	})
+}
+
+func (p *snapshots) stop() {
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+}
+
+// snapshotKey 快照的key(actorID or actorID.childID)
+func (p *Actor) snapshotKey() string {
+	if p.path.IsChild() {
+		return p.path.ActorID + cconst.DOT + p.path.ChildID
+	}
+
+	return p.path.ActorID
+}
+
+// restoreSnapshot 从store恢复actor状态
+func (p *Actor) restoreSnapshot() {
+	persistent, ok := p.handler.(IPersistent)
+	if !ok {
+		return
+	}
+
+	store := p.system.snapshots.getStore()
+	if store == nil {
+		return
+	}
+
+	data, err := store.Load(p.snapshotKey())
+	if err != nil {
+		clog.Warnf("[snapshot] Load fail. [path = %s, err = %v]", p.path, err)
+		return
+	}
+
+	if data == nil {
+		return
+	}
+
+	if _, isEventSourced := p.handler.(IEventSourced); isEventSourced {
+		seq, snapshot, found := decodeSeqSnapshot(data)
+		if !found {
+			clog.Warnf("[snapshot] Snapshot seq error. [path = %s]", p.path)
+			return
+		}
+		p.journalSeq, data = seq, snapshot
+	}
+
+	cutils.Try(func() {
+		persistent.Restore(data)
+	}, func(errString string) {
+		clog.Warnf("[snapshot] Restore fail. [path = %s, err = %s]", p.path, errString)
+	})
+}
+
+// saveSnapshot 保存actor状态到store
+func (p *Actor) saveSnapshot() {
+	persistent, ok := p.handler.(IPersistent)
+	if !ok {
+		return
+	}
+
+	store := p.system.snapshots.getStore()
+	if store == nil {
+		return
+	}
+
+	cutils.Try(func() {
+		data := persistent.Snapshot()
+		if data == nil {
+			return
+		}
+
+		if _, isEventSourced := p.handler.(IEventSourced); isEventSourced {
+			data = encodeSeqSnapshot(p.journalSeq, data)
+		}
+
+		if err := store.Save(p.snapshotKey(), data); err != nil {
+			clog.Warnf("[snapshot] Save fail. [path = %s, err = %v]", p.path, err)
+		}
+	}, func(errString string) {
+		clog.Warnf("[snapshot] Snapshot fail. [path = %s, err = %s]", p.path, errString)
+	})
+}
+
+// NewFileStore 创建基于文件的快照存储
+func NewFileStore(dir string) *FileStore {
+	if dir == "" {
+		dir = DefaultSnapshotDir
+	}
+
+	return &FileStore{
+		dir: dir,
+	}
+}
+
+func (p *FileStore) fileName(actorID string) string {
+	return filepath.Join(p.dir, url.PathEscape(actorID)+snapshotFileExt)
+}
+
+// Save 先写入临时文件再重命名,避免写入中断导致快照损坏
+func (p *FileStore) Save(actorID string, data []byte) error {
+	if err := os.MkdirAll(p.dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	fileName := p.fileName(actorID)
+	tmpName := fileName + ".tmp"
+
+	if err := os.WriteFile(tmpName, data, snapshotFileFileMode); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, fileName)
+}
+
+func (p *FileStore) Load(actorID string) ([]byte, error) {
+	data, err := os.ReadFile(p.fileName(actorID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return data, err
+}
+
+func (p *FileStore) Delete(actorID string) error {
+	err := os.Remove(p.fileName(actorID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,53 @@
+package cherryActor
+
+import (
+	"testing"
+	"time"
+)
+
+type testPersistentActor struct {
+	Base
+	state    string
+	restored chan string
+}
+
+func (p *testPersistentActor) OnInit() {
+	p.restored <- p.state
+}
+
+func (p *testPersistentActor) Snapshot() []byte {
+	return []byte(p.state)
+}
+
+func (p *testPersistentActor) Restore(data []byte) {
+	p.state = string(data)
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	system := NewSystem()
+	store := NewFileStore(t.TempDir())
+	system.SetSnapshotStore(store, 0)
+
+	first := &testPersistentActor{state: "level-10", restored: make(chan string, 1)}
+	system.CreateActor("player", first)
+	<-first.restored
+	first.Exit()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if data, _ := store.Load("player"); string(data) == "level-10" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("snapshot not saved")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	second := &testPersistentActor{restored: make(chan string, 1)}
+	system.CreateActor("player", second)
+
+	if state := <-second.restored; state != "level-10" {
+		t.Fatalf("state = %s", state)
+	}
+}
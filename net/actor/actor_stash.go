@@ -0,0 +1,90 @@
+package cherryActor
+
+import (
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+type (
+	// envelope 消息及其所在的邮箱
+	envelope struct {
+		mb      *mailbox
+		message *cfacade.Message
+	}
+
+	// actorStash 暂存消息,恢复后按原顺序在新消息之前处理
+	actorStash struct {
+		current   *envelope  // 正在处理的消息
+		stashed   bool       // 正在处理的消息是否已暂存
+		messages  []envelope // 已暂存的消息
+		unstashed []envelope // 已恢复待处理的消息
+	}
+)
+
+// Stash 暂存当前正在处理的消息,调用UnstashAll()后重新处理.只能在actor goroutine中调用
+// 暂存的消息由重新处理时的函数回复调用方
+func (p *Actor) Stash() {
+	current := p.stash.current
+	if current == nil || p.stash.stashed {
+		return
+	}
+
+	message := *current.message
+	p.stash.messages = append(p.stash.messages, envelope{
+		mb:      current.mb,
+		message: &message,
+	})
+
+	// 本次处理不再回复调用方
+	current.message.ChanResult = nil
+	current.message.ClusterReply = nil
+	p.stash.stashed = true
+}
+
+// UnstashAll 恢复所有暂存的消息,按原顺序在邮箱中的新消息之前处理
+func (p *Actor) UnstashAll() {
+	if len(p.stash.messages) < 1 {
+		return
+	}
+
+	p.stash.unstashed = append(p.stash.unstashed, p.stash.messages...)
+	p.stash.messages = nil
+}
+
+// StashSize 暂存的消息数量
+func (p *Actor) StashSize() int {
+	return len(p.stash.messages)
+}
+
+// setCurrent 设置正在处理的消息
+func (p *actorStash) setCurrent(mb *mailbox, m *cfacade.Message) {
+	if m == nil {
+		p.current = nil
+	} else {
+		p.current = &envelope{mb: mb, message: m}
+	}
+
+	p.stashed = false
+}
+
+// popUnstashed 取出一条已恢复的消息
+func (p *actorStash) popUnstashed() (envelope, bool) {
+	if len(p.unstashed) < 1 {
+		return envelope{}, false
+	}
+
+	item := p.unstashed[0]
+	p.unstashed[0] = envelope{}
+	p.unstashed = p.unstashed[1:]
+
+	return item, true
+}
+
+// drain 取出所有未处理的暂存消息
+func (p *actorStash) drain() []envelope {
+	list := append(p.unstashed, p.messages...)
+	p.unstashed = nil
+	p.messages = nil
+	p.current = nil
+
+	return list
+}
@@ -0,0 +1,72 @@
+package cherryActor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	creflect "github.com/cherry-game/cherry/extend/reflect"
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+type testRoomActor struct {
+	Base
+	joined chan string
+}
+
+func (p *testRoomActor) OnInit() {
+	p.Remote().Register("join", p.join)
+
+	// loading: 暂存join消息,加载完成后恢复
+	p.Become(func(_, remote IMailBox) {
+		remote.Register("join", func(_ *string) {
+			p.Stash()
+		})
+		remote.Register("loaded", func() {
+			p.Unbecome()
+			p.UnstashAll()
+		})
+	})
+}
+
+func (p *testRoomActor) join(name *string) {
+	p.joined <- *name
+}
+
+func TestStashAndBecome(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+
+	room := &testRoomActor{joined: make(chan string, 3)}
+	system.CreateActor("room", room)
+
+	call := func(funcName string, arg interface{}) {
+		system.Call(".caller", ".room", funcName, arg)
+	}
+
+	a, b, c := "a", "b", "c"
+	call("join", &a)
+	call("join", &b)
+	call("loaded", nil)
+	call("join", &c)
+
+	for _, want := range []string{"a", "b", "c"} {
+		select {
+		case name := <-room.joined:
+			if name != want {
+				t.Fatalf("name = %s, want = %s", name, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("wait %s timeout", want)
+		}
+	}
+}
+
+// directRemoteInvoke 不经过序列化直接调用remote函数
+func directRemoteInvoke(_ cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade.Message) {
+	values := make([]reflect.Value, fi.InArgsLen)
+	if fi.InArgsLen > 0 {
+		values[0] = reflect.ValueOf(m.Args)
+	}
+	fi.Value.Call(values)
+}
@@ -0,0 +1,124 @@
+package cherryActor
+
+import (
+	"time"
+
+	ctime "github.com/cherry-game/cherry/extend/time"
+)
+
+var (
+	ResumeDirective   Directive = 0 // 忽略异常,actor保持当前状态继续运行
+	RestartDirective  Directive = 1 // 重启actor(执行OnStop、重置函数注册及定时器、执行OnInit)
+	StopDirective     Directive = 2 // 停止actor
+	EscalateDirective Directive = 3 // 上报给父actor,由父actor的监督者处理(顶层actor则停止)
+)
+
+const (
+	minRestartBackoff    = 10 * time.Millisecond // 不限制重启次数时,连续重启的最小退避时间
+	maxRestartBackoff    = 10 * time.Second      // 不限制重启次数时,连续重启的最大退避时间
+	restartBackoffWindow = time.Minute           // 统计连续重启次数的默认时间窗口
+)
+
+type (
+	Directive int
+
+	// Decider 根据actor及panic内容决定处理指令
+	Decider func(thisActor *Actor, reason interface{}) Directive
+
+	// SupervisorStrategy 监督策略
+	//
+	// 子actor使用父actor(实现ISupervisor接口)声明的策略,顶层actor使用System的策略
+	SupervisorStrategy struct {
+		MaxRestarts int           // 时间窗口内允许的最大重启次数,超过后停止actor(<1表示不限制,连续重启时按指数退避)
+		Within      time.Duration // 统计重启次数的时间窗口(<1表示不限制)
+		Decider     Decider       // 指令决策函数
+	}
+
+	// ISupervisor actor handler实现该接口,可为其子actor声明监督策略
+	ISupervisor interface {
+		SupervisorStrategy() *SupervisorStrategy
+	}
+)
+
+// NewStrategy 创建监督策略,所有异常都返回相同的指令
+func NewStrategy(directive Directive, maxRestarts int, within time.Duration) *SupervisorStrategy {
+	return &SupervisorStrategy{
+		MaxRestarts: maxRestarts,
+		Within:      within,
+		Decider: func(_ *Actor, _ interface{}) Directive {
+			return directive
+		},
+	}
+}
+
+// DefaultStrategy 默认监督策略,记录日志后继续运行
+func DefaultStrategy() *SupervisorStrategy {
+	return NewStrategy(ResumeDirective, 0, 0)
+}
+
+func (p *SupervisorStrategy) decide(thisActor *Actor, reason interface{}) Directive {
+	if p.Decider == nil {
+		return ResumeDirective
+	}
+
+	return p.Decider(thisActor, reason)
+}
+
+// requestRestart 检查时间窗口内的重启次数,返回是否允许本次重启及重启前的退避时间
+func (p *SupervisorStrategy) requestRestart(thisActor *Actor) (bool, time.Duration) {
+	now := ctime.Now().ToMillisecond()
+
+	within := p.Within
+	if within < 1 && p.MaxRestarts < 1 {
+		within = restartBackoffWindow
+	}
+
+	if within > 0 {
+		windowStart := now - within.Milliseconds()
+
+		var restartTimes []int64
+		for _, restartAt := range thisActor.restartTimes {
+			if restartAt > windowStart {
+				restartTimes = append(restartTimes, restartAt)
+			}
+		}
+		thisActor.restartTimes = restartTimes
+	}
+
+	count := len(thisActor.restartTimes)
+	if p.MaxRestarts > 0 && count >= p.MaxRestarts {
+		return false, 0
+	}
+
+	thisActor.restartTimes = append(thisActor.restartTimes, now)
+
+	if p.MaxRestarts > 0 || count < 1 {
+		return true, 0
+	}
+
+	// 不限制重启次数时,连续重启按指数退避,避免OnInit反复panic时空转
+	backoff := maxRestartBackoff
+	if count <= 10 {
+		backoff = minRestartBackoff << (count - 1)
+		if backoff > maxRestartBackoff {
+			backoff = maxRestartBackoff
+		}
+	}
+
+	return true, backoff
+}
+
+func (d Directive) String() string {
+	switch d {
+	case ResumeDirective:
+		return "resume"
+	case RestartDirective:
+		return "restart"
+	case StopDirective:
+		return "stop"
+	case EscalateDirective:
+		return "escalate"
+	}
+
+	return "unknown"
+}
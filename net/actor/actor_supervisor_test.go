@@ -0,0 +1,247 @@
+package cherryActor
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+type testPanicActor struct {
+	Base
+	initCount int
+	stopCount int
+	panicInit int
+	done      chan struct{}
+}
+
+func (p *testPanicActor) OnInit() {
+	p.initCount++
+	if p.initCount <= p.panicInit {
+		panic("init panic")
+	}
+	close(p.done)
+}
+
+func (p *testPanicActor) OnStop() {
+	p.stopCount++
+}
+
+func TestSupervisorRestart(t *testing.T) {
+	system := NewSystem()
+	system.SetSupervisorStrategy(NewStrategy(RestartDirective, 3, time.Minute))
+
+	handler := &testPanicActor{panicInit: 2, done: make(chan struct{})}
+	system.CreateActor("restart", handler)
+
+	select {
+	case <-handler.done:
+	case <-time.After(time.Second):
+		t.Fatal("actor not restarted")
+	}
+
+	if handler.initCount != 3 || handler.stopCount != 2 {
+		t.Fatalf("initCount = %d, stopCount = %d", handler.initCount, handler.stopCount)
+	}
+}
+
+func TestSupervisorMaxRestarts(t *testing.T) {
+	strategy := NewStrategy(RestartDirective, 2, time.Minute)
+	thisActor := &Actor{}
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := strategy.requestRestart(thisActor); !ok {
+			t.Fatalf("restart %d should be allowed", i)
+		}
+	}
+
+	if ok, _ := strategy.requestRestart(thisActor); ok {
+		t.Fatal("restart should be denied")
+	}
+}
+
+func TestSupervisorUnlimitedBackoff(t *testing.T) {
+	strategy := NewStrategy(RestartDirective, 0, 0)
+	thisActor := &Actor{}
+
+	// 不限制重启次数,连续重启时退避时间递增
+	var last time.Duration
+	for i := 0; i < 20; i++ {
+		ok, backoff := strategy.requestRestart(thisActor)
+		if !ok {
+			t.Fatalf("restart %d should be allowed", i)
+		}
+
+		if i > 0 && (backoff < last || backoff <= 0) {
+			t.Fatalf("restart %d backoff = %v, last = %v", i, backoff, last)
+		}
+		last = backoff
+	}
+
+	if last != maxRestartBackoff {
+		t.Fatalf("backoff = %v", last)
+	}
+}
+
+type testStateActor struct {
+	Base
+	state   []int
+	handled chan []int
+}
+
+func (p *testStateActor) OnInit() {
+	p.Remote().Register("add", func() {
+		p.state = append(p.state, 1)
+		p.handled <- p.state
+	})
+	p.Remote().Register("boom", func() {
+		panic("boom")
+	})
+}
+
+func TestSupervisorRestartRebuildHandler(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+	system.SetSupervisorStrategy(NewStrategy(RestartDirective, 3, time.Minute))
+
+	var created int32
+	handled := make(chan []int, 2)
+	system.CreateActorByFactory("state", func(_ string) cfacade.IActorHandler {
+		atomic.AddInt32(&created, 1)
+		return &testStateActor{handled: handled}
+	})
+
+	for _, funcName := range []string{"add", "boom", "add"} {
+		m := newTestMessage(funcName)
+		m.Target = ".state"
+		system.PostRemote(m)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case state := <-handled:
+			// 重启后的handler不保留崩溃前的状态
+			if len(state) != 1 {
+				t.Fatalf("state = %v", state)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("message not handled")
+		}
+	}
+
+	if n := atomic.LoadInt32(&created); n != 2 {
+		t.Fatalf("created = %d", n)
+	}
+}
+
+type testInitPanicActor struct {
+	Base
+	initCount int
+	panicInit int
+	depths    []int
+	done      chan struct{}
+}
+
+func (p *testInitPanicActor) OnInit() {
+	p.initCount++
+	p.depths = append(p.depths, runtime.Callers(0, make([]uintptr, 256)))
+	if p.initCount <= p.panicInit {
+		panic("init panic")
+	}
+	close(p.done)
+}
+
+func TestSupervisorRestartNotRecursive(t *testing.T) {
+	system := NewSystem()
+	system.SetSupervisorStrategy(NewStrategy(RestartDirective, 100, time.Minute))
+
+	handler := &testInitPanicActor{panicInit: 50, done: make(chan struct{})}
+	system.CreateActor("initPanic", handler)
+
+	select {
+	case <-handler.done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("actor not restarted")
+	}
+
+	// 重启在actor loop中执行,调用栈深度不随重启次数增长
+	if first, last := handler.depths[1], handler.depths[len(handler.depths)-1]; first != last {
+		t.Fatalf("stack depth grows. first = %d, last = %d", first, last)
+	}
+}
+
+type testFailStopActor struct {
+	Base
+	started chan struct{}
+	release chan struct{}
+	worked  int32
+}
+
+func (p *testFailStopActor) OnInit() {
+	p.Remote().Register("boom", func() {
+		close(p.started)
+		<-p.release
+		panic("boom")
+	})
+	p.Remote().Register("work", func() {
+		atomic.AddInt32(&p.worked, 1)
+	})
+}
+
+func TestSupervisorStopDiscardMessages(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+	system.SetSupervisorStrategy(NewStrategy(StopDirective, 0, 0))
+
+	handler := &testFailStopActor{started: make(chan struct{}), release: make(chan struct{})}
+	thisActor, _ := system.CreateActor("stop", handler)
+
+	system.Call(".caller", ".stop", "boom", nil)
+	<-handler.started
+
+	for i := 0; i < 3; i++ {
+		system.Call(".caller", ".stop", "work", nil)
+	}
+	close(handler.release)
+
+	select {
+	case <-thisActor.(*Actor).stopped:
+	case <-time.After(time.Second):
+		t.Fatal("actor not stopped")
+	}
+
+	if worked := atomic.LoadInt32(&handler.worked); worked != 0 {
+		t.Fatalf("queued messages invoked after stop. worked = %d", worked)
+	}
+
+	if count := system.DeadLetterCount(ActorStoppedReason); count != 3 {
+		t.Fatalf("dead letter count = %d", count)
+	}
+}
+
+type testInitLoopActor struct {
+	Base
+	inits *int32
+}
+
+func (p *testInitLoopActor) OnInit() {
+	atomic.AddInt32(p.inits, 1)
+	panic("init panic")
+}
+
+func TestSupervisorUnlimitedRestartBackoff(t *testing.T) {
+	system := NewSystem()
+	system.SetSupervisorStrategy(NewStrategy(RestartDirective, 0, 0))
+	defer system.Stop()
+
+	var inits int32
+	system.CreateActor("initLoop", &testInitLoopActor{inits: &inits})
+
+	// OnInit反复panic时按退避时间重启,不会空转
+	time.Sleep(200 * time.Millisecond)
+	if n := atomic.LoadInt32(&inits); n < 2 || n > 10 {
+		t.Fatalf("inits = %d", n)
+	}
+}
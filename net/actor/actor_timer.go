@@ -1,8 +1,10 @@
 package cherryActor
 
 import (
+	"sort"
 	"time"
 
+	ctime "github.com/cherry-game/cherry/extend/time"
 	cherryTimeWheel "github.com/cherry-game/cherry/extend/time_wheel"
 	cutils "github.com/cherry-game/cherry/extend/utils"
 	clog "github.com/cherry-game/cherry/logger"
@@ -16,12 +18,26 @@ type (
 	actorTimer struct {
 		thisActor    *Actor
 		timerInfoMap map[uint64]*timerInfo //key:timerID,value:*timerInfo
+		persisted    map[string]int64      //key:持久化定时器名,value:下次触发时间(毫秒)
 	}
 
 	timerInfo struct {
-		timer *cherryTimeWheel.Timer
-		fn    func()
-		once  bool
+		timer    *cherryTimeWheel.Timer
+		fn       func()
+		once     bool
+		schedule ITimerSchedule // 循环定时器的调度
+		next     time.Time      // 下次触发时间
+		name     string         // 持久化定时器名
+		misfired int            // 待补执行的次数
+	}
+
+	// TimerItem 定时器信息
+	TimerItem struct {
+		ID         uint64
+		Name       string    // 持久化定时器名
+		Next       time.Time // 下次触发时间
+		Once       bool
+		Persistent bool
 	}
 )
 
@@ -37,6 +53,13 @@ func (p *actorTimer) onStop() {
 	p.thisActor = nil
 }
 
+// reset 移除所有定时器
+func (p *actorTimer) reset() {
+	p.RemoveAll()
+	p.timerInfoMap = make(map[uint64]*timerInfo)
+	p.persisted = nil
+}
+
 func (p *actorTimer) Add(delay time.Duration, fn func(), async ...bool) uint64 {
 	if delay.Milliseconds() < 1 || fn == nil {
 		clog.Warnf("[ActorTimer] Add parameter error. delay = %+v", delay)
@@ -51,7 +74,8 @@ func (p *actorTimer) Add(delay time.Duration, fn func(), async ...bool) uint64 {
 		return 0
 	}
 
-	p.addTimerInfo(timer, fn, false)
+	schedule := &cherryTimeWheel.EverySchedule{Interval: delay}
+	p.addTimerInfo(timer, fn, schedule, ctime.GetClock().Now().Add(delay), false)
 
 	return newID
 }
@@ -70,7 +94,7 @@ func (p *actorTimer) AddOnce(delay time.Duration, fn func(), async ...bool) uint
 		return 0
 	}
 
-	p.addTimerInfo(timer, fn, true)
+	p.addTimerInfo(timer, fn, nil, ctime.GetClock().Now().Add(delay), true)
 
 	return newID
 }
@@ -89,6 +113,17 @@ func (p *actorTimer) AddFixedMinute(minute, second int, fn func(), async ...bool
 	return p.AddFixedHour(-1, minute, second, fn, async...)
 }
 
+// AddCron 按cron表达式(秒 分 时 日 月 周)循环执行,未指定CRON_TZ时使用profile配置的time_zone
+func (p *actorTimer) AddCron(spec string, fn func(), async ...bool) uint64 {
+	schedule, err := cherryTimeWheel.ParseCron(spec, ctime.OffsetLocation())
+	if err != nil {
+		clog.Warnf("[ActorTimer] AddCron parameter error. [spec = %s, err = %v]", spec, err)
+		return 0
+	}
+
+	return p.AddSchedule(schedule, fn, async...)
+}
+
 func (p *actorTimer) AddSchedule(s ITimerSchedule, fn func(), async ...bool) uint64 {
 	if s == nil || fn == nil {
 		return 0
@@ -97,36 +132,73 @@ func (p *actorTimer) AddSchedule(s ITimerSchedule, fn func(), async ...bool) uin
 	newID := globalTimer.NextID()
 	timer := globalTimer.ScheduleFunc(newID, s, p.callUpdateTimer(newID), async...)
 
-	p.addTimerInfo(timer, fn, false)
+	if timer == nil {
+		clog.Warnf("[ActorTimer] AddSchedule error. schedule = %+v", s)
+		return 0
+	}
+
+	p.addTimerInfo(timer, fn, s, s.Next(ctime.GetClock().Now()), false)
 
 	return newID
 }
 
+// Remove 移除定时器,持久化定时器同时删除保存的触发时间
 func (p *actorTimer) Remove(id uint64) {
 	funcItem, found := p.timerInfoMap[id]
 	if found {
 		funcItem.timer.Stop()
 		delete(p.timerInfoMap, id)
+
+		if funcItem.name != "" {
+			p.deleteNext(funcItem.name)
+		}
 	}
 }
 
+// RemoveAll 停止所有定时器,保留持久化定时器保存的触发时间
 func (p *actorTimer) RemoveAll() {
 	for _, info := range p.timerInfoMap {
 		info.timer.Stop()
 	}
 }
 
-func (p *actorTimer) addTimerInfo(timer *cherryTimeWheel.Timer, fn func(), once bool) {
-	p.timerInfoMap[timer.ID()] = &timerInfo{
-		timer: timer,
-		fn:    fn,
-		once:  once,
+// List 获取所有定时器,按下次触发时间排序
+func (p *actorTimer) List() []TimerItem {
+	list := make([]TimerItem, 0, len(p.timerInfoMap))
+	for id, info := range p.timerInfoMap {
+		list = append(list, TimerItem{
+			ID:         id,
+			Name:       info.name,
+			Next:       info.next,
+			Once:       info.once,
+			Persistent: info.name != "",
+		})
 	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Next.Before(list[j].Next)
+	})
+
+	return list
+}
+
+func (p *actorTimer) addTimerInfo(timer *cherryTimeWheel.Timer, fn func(), s ITimerSchedule, next time.Time, once bool) *timerInfo {
+	info := &timerInfo{
+		timer:    timer,
+		fn:       fn,
+		once:     once,
+		schedule: s,
+		next:     next,
+	}
+
+	p.timerInfoMap[timer.ID()] = info
+
+	return info
 }
 
 func (p *actorTimer) callUpdateTimer(id uint64) func() {
 	return func() {
-		p.thisActor.Call(p.thisActor.PathString(), updateTimerFuncName, id)
+		p.thisActor.CallPriority(p.thisActor.PathString(), updateTimerFuncName, id)
 	}
 }
 
@@ -142,7 +214,22 @@ func (p *actorTimer) _updateTimer_(id uint64) {
 		clog.Error(errString)
 	})
 
+	// 补执行错过的触发,不推进下次触发时间
+	if value.misfired > 0 {
+		value.misfired--
+		return
+	}
+
 	if value.once {
 		delete(p.timerInfoMap, id)
+		return
+	}
+
+	if value.schedule != nil {
+		value.next = value.schedule.Next(value.next)
+	}
+
+	if value.name != "" {
+		p.saveNext(value.name, value.next)
 	}
 }
@@ -0,0 +1,215 @@
+package cherryActor
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	ctime "github.com/cherry-game/cherry/extend/time"
+	clog "github.com/cherry-game/cherry/logger"
+)
+
+var (
+	MisfireFireOnce MisfirePolicy = 0 // 错过的多次触发只补执行一次
+	MisfireFireAll  MisfirePolicy = 1 // 补执行所有错过的触发(最多maxMisfireCount次)
+	MisfireSkip     MisfirePolicy = 2 // 跳过错过的触发,等待下一次触发
+)
+
+const (
+	DefaultTimerDir = "./timer" // 默认的持久化定时器文件目录
+	maxMisfireCount = 1000
+	timerKeyPrefix  = "timer:" // 定时器存储的key前缀,与快照使用同一个store时不会互相覆盖
+)
+
+type (
+	MisfirePolicy int
+
+	// timerStores 持久化定时器的存储,每个actor保存一条记录(key:定时器名,value:下次触发时间)
+	timerStores struct {
+		sync.RWMutex
+		store ISnapshotStore
+	}
+
+	// resumeSchedule 第一次触发使用持久化的时间,之后按原调度执行
+	resumeSchedule struct {
+		ITimerSchedule
+		first time.Time
+	}
+)
+
+func (p *timerStores) set(store ISnapshotStore) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.store = store
+}
+
+// get 未设置时使用默认的文件存储
+func (p *timerStores) get() ISnapshotStore {
+	p.RLock()
+	store := p.store
+	p.RUnlock()
+
+	if store != nil {
+		return store
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.store == nil {
+		p.store = NewFileStore(DefaultTimerDir)
+	}
+
+	return p.store
+}
+
+func (s *resumeSchedule) Next(prev time.Time) time.Time {
+	if !s.first.IsZero() {
+		first := s.first
+		s.first = time.Time{}
+		return first
+	}
+
+	return s.ITimerSchedule.Next(prev)
+}
+
+// AddPersistent 添加持久化的定时器,下次触发时间保存到store,actor重建时按misfire策略补执行错过的触发
+// name 定时器名(actor内唯一,同名定时器会被替换)
+func (p *actorTimer) AddPersistent(name string, s ITimerSchedule, misfire MisfirePolicy, fn func(), async ...bool) uint64 {
+	if name == "" || s == nil || fn == nil {
+		clog.Warnf("[ActorTimer] AddPersistent parameter error. [name = %s]", name)
+		return 0
+	}
+
+	if id, found := p.findByName(name); found {
+		p.timerInfoMap[id].timer.Stop()
+		delete(p.timerInfoMap, id)
+	}
+
+	var (
+		now         = ctime.GetClock().Now()
+		missed      = 0
+		next, found = p.loadNext(name)
+	)
+
+	if found {
+		for !next.IsZero() && !next.After(now) && missed < maxMisfireCount {
+			missed++
+			next = s.Next(next)
+		}
+
+		if !next.IsZero() && !next.After(now) {
+			next = s.Next(now)
+		}
+	} else {
+		next = s.Next(now)
+	}
+
+	if next.IsZero() {
+		clog.Warnf("[ActorTimer] AddPersistent schedule error. [name = %s]", name)
+		return 0
+	}
+
+	newID := globalTimer.NextID()
+	schedule := &resumeSchedule{ITimerSchedule: s, first: next}
+	timer := globalTimer.ScheduleFunc(newID, schedule, p.callUpdateTimer(newID), async...)
+
+	info := p.addTimerInfo(timer, fn, s, next, false)
+	info.name = name
+
+	switch misfire {
+	case MisfireFireOnce:
+		if missed > 0 {
+			info.misfired = 1
+		}
+	case MisfireFireAll:
+		info.misfired = missed
+	}
+
+	for i := 0; i < info.misfired; i++ {
+		p.callUpdateTimer(newID)()
+	}
+
+	p.saveNext(name, next)
+
+	return newID
+}
+
+func (p *actorTimer) findByName(name string) (uint64, bool) {
+	for id, info := range p.timerInfoMap {
+		if info.name == name {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// timerKey 持久化定时器的key(timer:actorID or timer:actorID.childID)
+func (p *actorTimer) timerKey() string {
+	return timerKeyPrefix + p.thisActor.snapshotKey()
+}
+
+// loadPersisted 首次使用时从store加载该actor的持久化定时器
+func (p *actorTimer) loadPersisted() map[string]int64 {
+	if p.persisted != nil {
+		return p.persisted
+	}
+
+	p.persisted = make(map[string]int64)
+
+	data, err := p.thisActor.system.timerStores.get().Load(p.timerKey())
+	if err != nil {
+		clog.Warnf("[ActorTimer] Load fail. [path = %s, err = %v]", p.thisActor.path, err)
+		return p.persisted
+	}
+
+	if data != nil {
+		if err = json.Unmarshal(data, &p.persisted); err != nil {
+			clog.Warnf("[ActorTimer] Unmarshal fail. [path = %s, err = %v]", p.thisActor.path, err)
+		}
+	}
+
+	return p.persisted
+}
+
+func (p *actorTimer) loadNext(name string) (time.Time, bool) {
+	nextMS, found := p.loadPersisted()[name]
+	if !found {
+		return time.Time{}, false
+	}
+
+	return time.UnixMilli(nextMS), true
+}
+
+func (p *actorTimer) saveNext(name string, next time.Time) {
+	p.loadPersisted()[name] = next.UnixMilli()
+	p.savePersisted()
+}
+
+func (p *actorTimer) deleteNext(name string) {
+	delete(p.loadPersisted(), name)
+	p.savePersisted()
+}
+
+func (p *actorTimer) savePersisted() {
+	var (
+		store = p.thisActor.system.timerStores.get()
+		key   = p.timerKey()
+		err   error
+	)
+
+	if len(p.persisted) < 1 {
+		err = store.Delete(key)
+	} else {
+		var data []byte
+		if data, err = json.Marshal(p.persisted); err == nil {
+			err = store.Save(key, data)
+		}
+	}
+
+	if err != nil {
+		clog.Warnf("[ActorTimer] Save fail. [path = %s, err = %v]", p.thisActor.path, err)
+	}
+}
@@ -0,0 +1,77 @@
+package cherryActor
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	cherryTimeWheel "github.com/cherry-game/cherry/extend/time_wheel"
+)
+
+type testPersistentTimerActor struct {
+	Base
+	misfire MisfirePolicy
+	fired   chan struct{}
+	items   chan []TimerItem
+}
+
+func (p *testPersistentTimerActor) OnInit() {
+	schedule := &cherryTimeWheel.EverySchedule{Interval: time.Hour}
+	p.Timer().AddPersistent("reset", schedule, p.misfire, func() {
+		p.fired <- struct{}{}
+	})
+	p.items <- p.Timer().List()
+}
+
+func testMisfire(t *testing.T, misfire MisfirePolicy, want int) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+
+	store := NewFileStore(t.TempDir())
+	system.SetTimerStore(store)
+
+	missed := time.Now().Add(-150 * time.Minute).UnixMilli()
+	data, _ := json.Marshal(map[string]int64{"reset": missed})
+	store.Save(timerKeyPrefix+"player", data)
+	// 快照与定时器使用同一个store
+	store.Save("player", []byte("snapshot"))
+
+	handler := &testPersistentTimerActor{
+		misfire: misfire,
+		fired:   make(chan struct{}, 10),
+		items:   make(chan []TimerItem, 1),
+	}
+	system.CreateActor("player", handler)
+
+	items := <-handler.items
+	if len(items) != 1 || items[0].Name != "reset" || !items[0].Persistent {
+		t.Fatalf("items = %+v", items)
+	}
+
+	wantNext := time.UnixMilli(missed).Add(3 * time.Hour)
+	if !items[0].Next.Equal(wantNext) {
+		t.Fatalf("next = %v, want = %v", items[0].Next, wantNext)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if got := len(handler.fired); got != want {
+		t.Fatalf("fired = %d, want = %d", got, want)
+	}
+
+	if snapshot, _ := store.Load("player"); string(snapshot) != "snapshot" {
+		t.Fatalf("snapshot = %s", snapshot)
+	}
+
+	saved, _ := store.Load(timerKeyPrefix + "player")
+	next := map[string]int64{}
+	json.Unmarshal(saved, &next)
+	if next["reset"] != wantNext.UnixMilli() {
+		t.Fatalf("saved = %v", next)
+	}
+}
+
+func TestPersistentTimerMisfire(t *testing.T) {
+	testMisfire(t, MisfireFireOnce, 1)
+	testMisfire(t, MisfireFireAll, 3)
+	testMisfire(t, MisfireSkip, 0)
+}
@@ -0,0 +1,83 @@
+package cherryActor
+
+import (
+	"testing"
+	"time"
+
+	ctime "github.com/cherry-game/cherry/extend/time"
+)
+
+type testCronActor struct {
+	Base
+	ids   chan uint64
+	fired chan struct{}
+}
+
+func (p *testCronActor) OnInit() {
+	p.ids <- p.Timer().AddCron("bad spec", func() {})
+	p.ids <- p.Timer().AddCron("* * * * * *", func() {
+		p.fired <- struct{}{}
+	})
+}
+
+func TestAddCron(t *testing.T) {
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+
+	handler := &testCronActor{
+		ids:   make(chan uint64, 2),
+		fired: make(chan struct{}, 10),
+	}
+	system.CreateActor("cron", handler)
+
+	if id := <-handler.ids; id != 0 {
+		t.Fatalf("bad spec id = %d", id)
+	}
+
+	if id := <-handler.ids; id == 0 {
+		t.Fatal("cron id = 0")
+	}
+
+	select {
+	case <-handler.fired:
+	case <-time.After(2 * time.Second):
+		t.Fatal("cron not fired")
+	}
+}
+
+type testClockActor struct {
+	Base
+	fired chan time.Time
+}
+
+func (p *testClockActor) OnInit() {
+	p.Timer().Add(time.Hour, func() {
+		p.fired <- ctime.Now().Time
+	})
+	p.fired <- time.Time{}
+}
+
+func TestTimerManualClock(t *testing.T) {
+	clock := ctime.NewManualClock(time.Time{})
+	ctime.SetClock(clock)
+	defer ctime.SetClock(nil)
+
+	system := NewSystem()
+	system.SetRemoteInvoke(directRemoteInvoke)
+
+	handler := &testClockActor{fired: make(chan time.Time, 2)}
+	system.CreateActor("clock", handler)
+	<-handler.fired
+
+	start := clock.Now()
+	clock.Advance(time.Hour)
+
+	select {
+	case now := <-handler.fired:
+		if now.Sub(start) != time.Hour {
+			t.Fatalf("elapsed = %v", now.Sub(start))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timer not fired")
+	}
+}
@@ -0,0 +1,142 @@
+package cherryActor
+
+import (
+	"sync"
+
+	creflect "github.com/cherry-game/cherry/extend/reflect"
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+const (
+	topicKeySeparator = ":"
+)
+
+type (
+	// topics 主题订阅索引,发布时只投递给订阅者
+	topics struct {
+		sync.RWMutex
+		subscribers map[string]map[*Actor]struct{} // key:topic, value:subscribers
+	}
+
+	// topicEvent 通过主题投递的事件
+	topicEvent struct {
+		cfacade.IEventData
+		topic string
+	}
+)
+
+func newTopics() *topics {
+	return &topics{
+		subscribers: make(map[string]map[*Actor]struct{}),
+	}
+}
+
+// topicKey 主题名 = 事件名[:key], 如 guildChat:123
+func topicKey(name, key string) string {
+	if key == "" {
+		return name
+	}
+
+	return name + topicKeySeparator + key
+}
+
+func (p *topics) subscribe(topic string, thisActor *Actor) {
+	p.Lock()
+	defer p.Unlock()
+
+	actors, found := p.subscribers[topic]
+	if !found {
+		actors = make(map[*Actor]struct{})
+		p.subscribers[topic] = actors
+	}
+
+	actors[thisActor] = struct{}{}
+}
+
+func (p *topics) unsubscribe(topic string, thisActor *Actor) {
+	p.Lock()
+	defer p.Unlock()
+
+	if actors, found := p.subscribers[topic]; found {
+		delete(actors, thisActor)
+		if len(actors) < 1 {
+			delete(p.subscribers, topic)
+		}
+	}
+}
+
+func (p *topics) list(topic string) []*Actor {
+	p.RLock()
+	defer p.RUnlock()
+
+	actors := p.subscribers[topic]
+	list := make([]*Actor, 0, len(actors))
+	for thisActor := range actors {
+		list = append(list, thisActor)
+	}
+
+	return list
+}
+
+func (p *topics) count(topic string) int {
+	p.RLock()
+	defer p.RUnlock()
+
+	return len(p.subscribers[topic])
+}
+
+// publish 投递事件给主题的所有订阅者
+func (p *topics) publish(topic string, data cfacade.IEventData) {
+	for _, thisActor := range p.list(topic) {
+		if thisActor.State() == WorkerState {
+			thisActor.event.pushTopic(topic, data)
+		}
+	}
+}
+
+// Subscribe 订阅主题,keys为空则订阅事件名,否则订阅 事件名:key
+func (p *actorEvent) Subscribe(name string, fn IEventFunc, keys ...string) {
+	funcInfo, ok := eventFuncInfo(name, fn)
+	if !ok {
+		return
+	}
+
+	if len(keys) < 1 {
+		keys = []string{""}
+	}
+
+	for _, key := range keys {
+		topic := topicKey(name, key)
+		p.topicFuncs[topic] = append(p.topicFuncs[topic], funcInfo)
+		p.thisActor.system.topics.subscribe(topic, p.thisActor)
+	}
+}
+
+// Unsubscribe 取消订阅主题
+func (p *actorEvent) Unsubscribe(name string, keys ...string) {
+	if len(keys) < 1 {
+		keys = []string{""}
+	}
+
+	for _, key := range keys {
+		topic := topicKey(name, key)
+		delete(p.topicFuncs, topic)
+		p.thisActor.system.topics.unsubscribe(topic, p.thisActor)
+	}
+}
+
+func (p *actorEvent) unsubscribeAll() {
+	for topic := range p.topicFuncs {
+		p.thisActor.system.topics.unsubscribe(topic, p.thisActor)
+	}
+
+	p.topicFuncs = make(map[string][]*creflect.FuncInfo)
+}
+
+// pushTopic 与事件使用相同的溢出策略入队
+func (p *actorEvent) pushTopic(topic string, data cfacade.IEventData) {
+	p.offerEvent(&topicEvent{
+		IEventData: data,
+		topic:      topic,
+	}, topic)
+}
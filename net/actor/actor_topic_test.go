@@ -0,0 +1,98 @@
+package cherryActor
+
+import (
+	"testing"
+	"time"
+
+	cfacade "github.com/cherry-game/cherry/facade"
+)
+
+type testTopicEvent struct{}
+
+func (*testTopicEvent) Name() string {
+	return "guildChat"
+}
+
+func (*testTopicEvent) UniqueID() int64 {
+	return 0
+}
+
+type testTopicActor struct {
+	Base
+	key      string
+	received chan string
+}
+
+func (p *testTopicActor) OnInit() {
+	p.Event().Subscribe("guildChat", func(_ cfacade.IEventData) {
+		p.received <- p.ActorID()
+	}, p.key)
+}
+
+func TestTopicPublish(t *testing.T) {
+	system := NewSystem()
+	received := make(chan string, 2)
+
+	guild1 := &testTopicActor{key: "1", received: received}
+	guild2 := &testTopicActor{key: "2", received: received}
+	system.CreateActor("member1", guild1)
+	system.CreateActor("member2", guild2)
+
+	deadline := time.Now().Add(time.Second)
+	for system.SubscriberCount("guildChat", "1") < 1 || system.SubscriberCount("guildChat", "2") < 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscribe timeout")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	system.Publish(&testTopicEvent{}, "1")
+
+	if actorID := <-received; actorID != "member1" {
+		t.Fatalf("actorID = %s", actorID)
+	}
+
+	guild1.Exit()
+	for system.SubscriberCount("guildChat", "1") > 0 {
+		if time.Now().After(deadline.Add(time.Second)) {
+			t.Fatal("auto unsubscribe fail")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	select {
+	case actorID := <-received:
+		t.Fatalf("unexpected event. actorID = %s", actorID)
+	default:
+	}
+}
+
+type testSeqEvent struct {
+	seq int64
+}
+
+func (*testSeqEvent) Name() string {
+	return "guildChat"
+}
+
+func (e *testSeqEvent) UniqueID() int64 {
+	return e.seq
+}
+
+func TestTopicDropOldest(t *testing.T) {
+	thisActor, _ := newActor("topic", "", &testActor{}, NewSystem())
+	thisActor.event.SetCapacity(2, DropOldestPolicy)
+
+	// 主题事件与普通事件使用相同的溢出策略
+	for i := int64(1); i <= 3; i++ {
+		thisActor.event.pushTopic("guildChat:1", &testSeqEvent{seq: i})
+	}
+
+	if count := thisActor.event.Count(); count != 2 {
+		t.Fatalf("count = %d", count)
+	}
+
+	if data := thisActor.event.Pop(); data.UniqueID() != 2 {
+		t.Fatalf("oldest = %d", data.UniqueID())
+	}
+}
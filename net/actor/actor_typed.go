@@ -0,0 +1,106 @@
+package cherryActor
+
+import (
+	"reflect"
+
+	ccode "github.com/cherry-game/cherry/code"
+	cerror "github.com/cherry-game/cherry/error"
+	creflect "github.com/cherry-game/cherry/extend/reflect"
+	cfacade "github.com/cherry-game/cherry/facade"
+	cproto "github.com/cherry-game/cherry/net/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	protobufSerializerName = "protobuf"
+)
+
+var (
+	sessionType      = reflect.TypeOf(&cproto.Session{})
+	int32Type        = reflect.TypeOf(int32(0))
+	protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+)
+
+// Request 泛型同步调用,等待目标actor的remote函数返回Rsp
+func Request[Req, Rsp any](actor cfacade.IActor, targetPath, funcName string, req *Req) (*Rsp, int32) {
+	rsp := new(Rsp)
+
+	code := actor.CallWait(targetPath, funcName, req, rsp)
+	if ccode.IsFail(code) {
+		return nil, code
+	}
+
+	return rsp, code
+}
+
+// Notify 泛型异步调用,不等待目标actor返回
+func Notify[Req any](actor cfacade.IActor, targetPath, funcName string, req *Req) int32 {
+	return actor.Call(targetPath, funcName, req)
+}
+
+// RegisterLocal 注册local函数(处理客户端消息)
+func RegisterLocal[Req any](mb IMailBox, funcName string, fn func(session *cproto.Session, req *Req)) error {
+	return mb.RegisterFunc(funcName, fn)
+}
+
+// RegisterRequest 注册需要返回结果的remote函数,调用方使用Request
+func RegisterRequest[Req, Rsp any](mb IMailBox, funcName string, fn func(req *Req) (*Rsp, int32)) error {
+	return mb.RegisterFunc(funcName, fn)
+}
+
+// RegisterNotify 注册不返回结果的remote函数,调用方使用Notify
+func RegisterNotify[Req any](mb IMailBox, funcName string, fn func(req *Req)) error {
+	return mb.RegisterFunc(funcName, fn)
+}
+
+// validateFunc 校验函数签名是否满足invoke的调用要求
+//
+// local:  func(session *cproto.Session, req Req)
+// remote: func([req Req]) [int32 | (Rsp, int32)]
+// Req/Rsp可以是指针或值类型(如uint64),使用protobuf序列化时必须实现proto.Message
+func validateFunc(mailName string, fi *creflect.FuncInfo, serializer cfacade.ISerializer) error {
+	var argTypes []reflect.Type
+
+	if mailName == LocalName {
+		if fi.InArgsLen != 2 || fi.InArgs[0] != sessionType {
+			return cerror.Errorf("local func args must be (*cproto.Session, Req). [type = %v]", fi.Type)
+		}
+
+		if fi.OutArgsLen != 0 {
+			return cerror.Errorf("local func cannot have return values. [type = %v]", fi.Type)
+		}
+
+		argTypes = append(argTypes, fi.InArgs[1])
+	} else {
+		if fi.InArgsLen > 1 {
+			return cerror.Errorf("remote func args must be () or (Req). [type = %v]", fi.Type)
+		}
+
+		switch fi.OutArgsLen {
+		case 0:
+		case 1:
+			if fi.OutArgs[0] != int32Type {
+				return cerror.Errorf("remote func return value must be int32. [type = %v]", fi.Type)
+			}
+		case 2:
+			if fi.OutArgs[1] != int32Type {
+				return cerror.Errorf("remote func return values must be (Rsp, int32). [type = %v]", fi.Type)
+			}
+			argTypes = append(argTypes, fi.OutArgs[0])
+		default:
+			return cerror.Errorf("remote func return values must be int32 or (Rsp, int32). [type = %v]", fi.Type)
+		}
+
+		argTypes = append(argTypes, fi.InArgs...)
+	}
+
+	if serializer != nil && serializer.Name() == protobufSerializerName {
+		for _, argType := range argTypes {
+			if !argType.Implements(protoMessageType) {
+				return cerror.Errorf("%v is not proto.Message. [type = %v]", argType, fi.Type)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package cherryActor
+
+import (
+	"testing"
+
+	cfacade "github.com/cherry-game/cherry/facade"
+	cproto "github.com/cherry-game/cherry/net/proto"
+	cserializer "github.com/cherry-game/cherry/net/serializer"
+)
+
+func TestRegisterFuncValidate(t *testing.T) {
+	local := newMailbox(LocalName)
+	if err := RegisterLocal(&local, "login", func(_ *cproto.Session, _ *cproto.Response) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := local.RegisterFunc("bad", func(_ *cproto.Response) {}); err == nil {
+		t.Fatal("local func without session must fail")
+	}
+
+	remote := newMailbox(RemoteName)
+	remote.serializer = func() cfacade.ISerializer { return cserializer.NewProtobuf() }
+
+	if err := RegisterRequest(&remote, "get", func(_ *cproto.Session) (*cproto.Response, int32) { return nil, 0 }); err != nil {
+		t.Fatal(err)
+	}
+
+	type plain struct{}
+	if err := RegisterNotify(&remote, "plain", func(_ *plain) {}); err == nil {
+		t.Fatal("non proto.Message arg must fail with protobuf serializer")
+	}
+
+	if err := remote.RegisterFunc("value", func(_ int) {}); err == nil {
+		t.Fatal("non proto.Message value arg must fail with protobuf serializer")
+	}
+
+	jsonRemote := newMailbox(RemoteName)
+	jsonRemote.serializer = func() cfacade.ISerializer { return cserializer.NewJSON() }
+
+	if err := jsonRemote.RegisterFunc("kick", func(_ uint64) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := jsonRemote.RegisterFunc("level", func(_ uint64) (int32, int32) { return 0, 0 }); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -27,6 +27,8 @@ func (c *Component) Init() {
 }
 
 func (c *Component) OnAfterInit() {
+	c.System.onAfterInit()
+
 	// Register actor
 	for _, actor := range c.actorHandlers {
 		c.CreateActor(actor.AliasID(), actor)
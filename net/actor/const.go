@@ -8,6 +8,10 @@ var (
 	ErrForbiddenToCallSelf       = cerror.Errorf("SendActorID cannot be equal to TargetActorID")
 	ErrForbiddenCreateChildActor = cerror.Errorf("Forbidden create child actor")
 	ErrActorIDIsNil              = cerror.Error("actorID is nil.")
+	ErrNotEventSourced           = cerror.Error("actor handler is not IEventSourced.")
+	ErrJournalNotSet             = cerror.Error("journal is not set.")
+	ErrJournalCorrupted          = cerror.Error("journal record is corrupted.")
+	ErrActorFactoryIsNil         = cerror.Error("actor factory is nil.")
 )
 
 const (
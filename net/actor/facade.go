@@ -11,13 +11,18 @@ type (
 	IActorLoader interface {
 		load(actor *Actor)
 	}
+
+	AsyncCallback func(reply interface{}, code int32) // 异步调用的回复函数
 )
 
 type (
 	IEvent interface {
-		Register(name string, fn IEventFunc)     // 注册事件
-		Registers(names []string, fn IEventFunc) // 注册多个事件
-		Unregister(name string)                  // 注销事件
+		Register(name string, fn IEventFunc)                                              // 注册事件
+		Registers(names []string, fn IEventFunc)                                          // 注册多个事件
+		Unregister(name string)                                                           // 注销事件
+		Subscribe(name string, fn IEventFunc, keys ...string)                             // 订阅主题(事件名[:key])
+		Unsubscribe(name string, keys ...string)                                          // 取消订阅主题
+		SetCapacity(capacity int32, policy OverflowPolicy, blockTimeout ...time.Duration) // 设置事件队列容量及溢出策略
 	}
 
 	IEventFunc func(cfacade.IEventData) // 接收事件数据时的处理函数
@@ -25,20 +30,60 @@ type (
 
 type (
 	IMailBox interface {
-		Register(funcName string, fn interface{}) // 注册执行函数
+		Register(funcName string, fn interface{})           // 注册执行函数
+		RegisterFunc(funcName string, fn interface{}) error // 注册执行函数,注册时校验函数签名
 		GetFuncInfo(funcName string) (*creflect.FuncInfo, bool)
+		SetCapacity(capacity int32, policy OverflowPolicy, blockTimeout ...time.Duration) // 设置邮箱容量及溢出策略
+	}
+)
+
+type (
+	// IActorRegistry actor注册中心,记录actor所在的节点
+	IActorRegistry interface {
+		Register(actorID, nodeID string)      // 注册actor所在的节点
+		Unregister(actorID, nodeID string)    // 注销actor
+		Lookup(actorID string) (string, bool) // 查询actor所在的节点
+	}
+)
+
+type (
+	// IPersistent 需要持久化状态的actor handler实现该接口
+	IPersistent interface {
+		Snapshot() []byte    // 生成状态快照,返回nil则不保存
+		Restore(data []byte) // 从快照恢复状态,在OnInit()之前执行
+	}
+
+	// ISnapshotStore actor状态快照存储
+	ISnapshotStore interface {
+		Save(actorID string, data []byte) error // 保存快照
+		Load(actorID string) ([]byte, error)    // 加载快照,不存在时返回nil
+		Delete(actorID string) error            // 删除快照
+	}
+
+	// IEventSourced 事件溯源actor的handler实现该接口,通过Actor.Persist()持久化领域事件
+	IEventSourced interface {
+		ApplyEvent(data []byte) // 应用领域事件(持久化成功后及回放时执行)
+	}
+
+	// IJournal 只追加的事件日志,同一个actor的事件按seq有序
+	IJournal interface {
+		Append(actorID string, seq int64, data []byte) error                               // 追加事件
+		Replay(actorID string, fromSeq int64, fn func(seq int64, data []byte) error) error // 回放seq > fromSeq的事件
 	}
 )
 
 type (
 	ITimer interface {
-		Add(d time.Duration, fn func(), async ...bool) uint64                   // 添加定时器,循环执行
-		AddOnce(d time.Duration, fn func(), async ...bool) uint64               // 添加定时器,执行一次
-		AddFixedHour(hour, minute, second int, fn func(), async ...bool) uint64 // 固定x小时x分x秒,循环执行
-		AddFixedMinute(minute, second int, fn func(), async ...bool) uint64     // 固定x分x秒,循环执行
-		AddSchedule(s ITimerSchedule, f func(), async ...bool) uint64           // 添加自定义调度
-		Remove(id uint64)                                                       // 移除定时器
-		RemoveAll()                                                             // 移除所有定时器
+		Add(d time.Duration, fn func(), async ...bool) uint64                                               // 添加定时器,循环执行
+		AddOnce(d time.Duration, fn func(), async ...bool) uint64                                           // 添加定时器,执行一次
+		AddFixedHour(hour, minute, second int, fn func(), async ...bool) uint64                             // 固定x小时x分x秒,循环执行
+		AddFixedMinute(minute, second int, fn func(), async ...bool) uint64                                 // 固定x分x秒,循环执行
+		AddCron(spec string, fn func(), async ...bool) uint64                                               // 按cron表达式(秒 分 时 日 月 周)循环执行
+		AddSchedule(s ITimerSchedule, f func(), async ...bool) uint64                                       // 添加自定义调度
+		AddPersistent(name string, s ITimerSchedule, misfire MisfirePolicy, f func(), async ...bool) uint64 // 添加持久化定时器,重建时按misfire策略补执行
+		Remove(id uint64)                                                                                   // 移除定时器
+		RemoveAll()                                                                                         // 移除所有定时器
+		List() []TimerItem                                                                                  // 获取所有定时器及下次触发时间
 	}
 
 	ITimerSchedule interface {
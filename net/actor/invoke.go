@@ -9,7 +9,6 @@ import (
 	ccode "github.com/cherry-game/cherry/code"
 	cerror "github.com/cherry-game/cherry/error"
 	creflect "github.com/cherry-game/cherry/extend/reflect"
-	cutils "github.com/cherry-game/cherry/extend/utils"
 	cfacade "github.com/cherry-game/cherry/facade"
 	clog "github.com/cherry-game/cherry/logger"
 	cproto "github.com/cherry-game/cherry/net/proto"
@@ -21,7 +20,15 @@ func InvokeLocalFunc(app cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade
 		return
 	}
 
-	EncodeLocalArgs(app, fi, m)
+	if m.IsAborted() {
+		return
+	}
+
+	if err := EncodeLocalArgs(app, fi, m); err != nil {
+		clog.Warn(err)
+		m.Reply(ccode.ActorUnmarshalError)
+		return
+	}
 
 	values := make([]reflect.Value, 2)
 	values[0] = reflect.ValueOf(m.Session) // session
@@ -41,54 +48,32 @@ func InvokeRemoteFunc(app cfacade.IApplication, fi *creflect.FuncInfo, m *cfacad
 		return
 	}
 
-	EncodeRemoteArgs(app, fi, m)
+	if m.IsAborted() {
+		return
+	}
+
+	if err := EncodeRemoteArgs(app, fi, m); err != nil {
+		clog.Warn(err)
+		m.Reply(ccode.ActorUnmarshalError)
+		return
+	}
 
 	values := make([]reflect.Value, fi.InArgsLen)
 	if fi.InArgsLen > 0 {
 		values[0] = reflect.ValueOf(m.Args) // args
 	}
 
-	if m.IsCluster {
-		cutils.Try(func() {
-			rets := fi.Value.Call(values)
-			rspCode, rspData := retValue(app.Serializer(), rets)
-
-			retResponse(m.ClusterReply, &cproto.Response{
-				Code: rspCode,
-				Data: rspData,
-			})
-
-		}, func(errString string) {
-			retResponse(m.ClusterReply, &cproto.Response{
-				Code: ccode.RPCRemoteExecuteError,
-			})
-			clog.Errorf("[InvokeRemoteFunc] invoke error. [message = %+v, err = %s]", m, errString)
-		})
-	} else {
-		cutils.Try(func() {
-			if m.ChanResult == nil {
-				fi.Value.Call(values)
-			} else {
-				rets := fi.Value.Call(values)
-				rspCode, rspData := retValue(app.Serializer(), rets)
-				m.ChanResult <- &cproto.Response{
-					Code: rspCode,
-					Data: rspData,
-				}
-			}
-		}, func(errString string) {
-			if m.ChanResult != nil {
-				m.ChanResult <- nil
-			}
+	// panic直接抛给中间件链及actor监督处理,由invokeFunc统一回复调用方
+	rets := fi.Value.Call(values)
 
-			clog.Errorf("[remote] invoke error.[source = %s, target = %s -> %s, funcType = %v, err = %+v]",
-				m.Source,
-				m.Target,
-				m.FuncName,
-				fi.InArgs,
-				errString,
-			)
-		})
+	// 消息被暂存(Stash)时ChanResult为nil,不回复调用方
+	if m.ChanResult == nil && m.ClusterReply == nil {
+		return
+	}
+
+	rspCode, rspData := retValue(app.Serializer(), rets)
+	if err := m.Respond(&cproto.Response{Code: rspCode, Data: rspData}); err != nil {
+		clog.Warn(err)
 	}
 }
 
@@ -114,6 +99,11 @@ func EncodeArgs(app cfacade.IApplication, fi *creflect.FuncInfo, index int, m *c
 		var ok bool
 		argBytes, ok = m.Args.([]byte)
 		if !ok {
+			// 已在中间件链之前反序列化
+			if argsDecoded(fi, index, m) {
+				return nil
+			}
+
 			return cerror.Errorf("Encode args error.[source = %s, target = %s -> %s, funcType = %v]",
 				m.Source,
 				m.Target,
@@ -123,8 +113,15 @@ func EncodeArgs(app cfacade.IApplication, fi *creflect.FuncInfo, index int, m *c
 		}
 	}
 
-	argValue := reflect.New(fi.InArgs[index].Elem()).Interface()
-	err := app.Serializer().Unmarshal(argBytes, argValue)
+	// 参数为值类型(如uint64)时,反序列化到新建的指针后取值
+	argType := fi.InArgs[index]
+	isPtr := argType.Kind() == reflect.Ptr
+	if isPtr {
+		argType = argType.Elem()
+	}
+
+	argValue := reflect.New(argType)
+	err := app.Serializer().Unmarshal(argBytes, argValue.Interface())
 	if err != nil {
 		return cerror.Errorf("Encode args unmarshal error.[source = %s, target = %s -> %s, funcType = %v]",
 			m.Source,
@@ -134,11 +131,24 @@ func EncodeArgs(app cfacade.IApplication, fi *creflect.FuncInfo, index int, m *c
 		)
 	}
 
-	m.Args = argValue
+	if isPtr {
+		m.Args = argValue.Interface()
+	} else {
+		m.Args = argValue.Elem().Interface()
+	}
 
 	return nil
 }
 
+// argsDecoded 参数是否已反序列化为函数的参数类型
+func argsDecoded(fi *creflect.FuncInfo, index int, m *cfacade.Message) bool {
+	if index >= len(fi.InArgs) {
+		return false
+	}
+
+	return reflect.TypeOf(m.Args).AssignableTo(fi.InArgs[index])
+}
+
 func retValue(serializer cfacade.ISerializer, rets []reflect.Value) (int32, []byte) {
 	var (
 		retsLen = len(rets)
@@ -153,7 +163,7 @@ func retValue(serializer cfacade.ISerializer, rets []reflect.Value) (int32, []by
 			}
 		}
 	} else if retsLen == 2 {
-		if !rets[0].IsNil() {
+		if !isNilValue(rets[0]) {
 			data, err := serializer.Marshal(rets[0].Interface())
 			if err != nil {
 				rspCode = ccode.RPCRemoteExecuteError
@@ -173,6 +183,15 @@ func retValue(serializer cfacade.ISerializer, rets []reflect.Value) (int32, []by
 	return rspCode, rspData
 }
 
+func isNilValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return value.IsNil()
+	}
+
+	return false
+}
+
 func retResponse(reply cfacade.IRespond, rsp *cproto.Response) {
 	if reply != nil {
 		rspData, _ := proto.Marshal(rsp)
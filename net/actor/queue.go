@@ -10,6 +10,7 @@ type (
 		head, tail *queueNode
 		C          chan int32
 		count      int32
+		onPush     func() // 入队后执行(PoolMode唤醒actor)
 	}
 
 	queueNode struct {
@@ -43,6 +44,10 @@ func (p *queue) Push(v interface{}) {
 	atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&prev.next)), unsafe.Pointer(n))
 
 	p._setCount(1)
+
+	if p.onPush != nil {
+		p.onPush()
+	}
 }
 
 func (p *queue) Pop() interface{} {
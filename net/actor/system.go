@@ -3,10 +3,11 @@ package cherryActor
 import (
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	ccode "github.com/cherry-game/cherry/code"
-	cutils "github.com/cherry-game/cherry/extend/utils"
+	ctime "github.com/cherry-game/cherry/extend/time"
 	cfacade "github.com/cherry-game/cherry/facade"
 	clog "github.com/cherry-game/cherry/logger"
 	cproto "github.com/cherry-game/cherry/net/proto"
@@ -15,28 +16,61 @@ import (
 type (
 	// System Actor系统
 	System struct {
-		app              cfacade.IApplication
-		actorMap         *sync.Map          // key:actorID, value:*actor
-		localInvokeFunc  cfacade.InvokeFunc // default local func
-		remoteInvokeFunc cfacade.InvokeFunc // default remote func
-		wg               *sync.WaitGroup    // wait group
-		callTimeout      time.Duration      // call调用超时
-		arrivalTimeOut   int64              // message到达超时(毫秒)
-		executionTimeout int64              // 消息执行超时(毫秒)
+		app                cfacade.IApplication
+		actorMap           *sync.Map           // key:actorID, value:*actor
+		localInvokeFunc    cfacade.InvokeFunc  // default local func
+		remoteInvokeFunc   cfacade.InvokeFunc  // default remote func
+		wg                 *sync.WaitGroup     // wait group
+		callTimeout        time.Duration       // call调用超时
+		arrivalTimeOut     int64               // message到达超时(毫秒)
+		executionTimeout   int64               // 消息执行超时(毫秒)
+		supervisorStrategy *SupervisorStrategy // 顶层actor的监督策略
+		mailboxOverflow    overflow            // 新建actor的默认邮箱容量及溢出策略
+		deadLetters        *deadLetters        // 无法投递的消息
+		passivation        *passivation        // 空闲actor钝化
+		location           *location           // actor位置服务
+		singletons         *singletons         // 集群单例actor
+		invokeChain        invokeChain         // invoke中间件
+		snapshots          *snapshots          // actor状态快照
+		journals           journals            // 事件溯源actor的事件日志
+		timerStores        timerStores         // 持久化定时器的存储
+		eventBus           *eventBus           // 集群事件总线
+		topics             *topics             // 主题订阅索引
+		routers            routers             // 路由池
+		stopping           int32               // 是否正在停止
+		stopTimeout        time.Duration       // 停止的截止时间
+		stopAt             time.Time           // 本次停止的截止时间,父actor及子actor共用
+		forceStopped       int32               // 停止超时被强制退出的actor数量(包括子actor)
+		stopOrder          []string            // 顶层actor的停止顺序(actorID前缀)
+		scheduler          *scheduler          // PoolMode actor的调度器
+		schedulerMode      SchedulerMode       // 新建actor的默认调度方式
 	}
 )
 
 func NewSystem() *System {
 	system := &System{
-		actorMap:         &sync.Map{},
-		localInvokeFunc:  InvokeLocalFunc,
-		remoteInvokeFunc: InvokeRemoteFunc,
-		wg:               &sync.WaitGroup{},
-		callTimeout:      3 * time.Second,
-		arrivalTimeOut:   100,
-		executionTimeout: 100,
+		actorMap:           &sync.Map{},
+		localInvokeFunc:    InvokeLocalFunc,
+		remoteInvokeFunc:   InvokeRemoteFunc,
+		wg:                 &sync.WaitGroup{},
+		callTimeout:        3 * time.Second,
+		arrivalTimeOut:     100,
+		executionTimeout:   100,
+		supervisorStrategy: DefaultStrategy(),
+		mailboxOverflow:    newOverflow(),
+		deadLetters:        newDeadLetters(),
+		stopTimeout:        defaultStopTimeout,
+		scheduler:          newScheduler(),
 	}
 
+	system.passivation = newPassivation(system)
+	system.location = newLocation(system)
+	system.singletons = newSingletons(system)
+	system.snapshots = newSnapshots(system)
+	system.eventBus = newEventBus(system)
+	system.topics = newTopics()
+	system.buildInvokeChain()
+
 	return system
 }
 
@@ -44,6 +78,16 @@ func (p *System) SetApp(app cfacade.IApplication) {
 	p.app = app
 }
 
+// onAfterInit 所有组件初始化完成后执行(discovery组件已加载)
+func (p *System) onAfterInit() {
+	if discovery := p.app.Discovery(); discovery != nil {
+		discovery.OnRemoveMember(p.location.invalidateNode)
+	}
+
+	p.singletons.onAfterInit()
+	p.eventBus.onAfterInit()
+}
+
 func (p *System) NodeID() string {
 	if p.app == nil {
 		return ""
@@ -52,21 +96,39 @@ func (p *System) NodeID() string {
 	return p.app.NodeID()
 }
 
+func (p *System) serializer() cfacade.ISerializer {
+	if p.app == nil {
+		return nil
+	}
+
+	return p.app.Serializer()
+}
+
 func (p *System) Stop() {
-	p.actorMap.Range(func(key, value any) bool {
-		actor, ok := value.(*Actor)
-		if ok {
-			cutils.Try(func() {
-				actor.Exit()
-			}, func(err string) {
-				clog.Warnf("[OnStop] - [actorID = %s, err = %s]", actor.path, err)
-			})
-		}
-		return true
-	})
+	p.stopAt = p.stopDeadline()
+	atomic.StoreInt32(&p.stopping, 1)
+
+	p.routers.stop()
+	p.passivation.stop()
+	p.location.stop()
+	p.snapshots.stop()
 
 	clog.Info("actor system stopping!")
+
+	for _, thisActor := range p.drain() {
+		clog.Warnf("[Stop] Actor stop timeout. [%s]", thisActor.report())
+		thisActor.forceStop()
+	}
+
+	// 被强制退出的actor(包括父actor等待超时的子actor)可能一直阻塞,不再等待
+	if stuck := atomic.LoadInt32(&p.forceStopped); stuck > 0 {
+		p.scheduler.stop(false)
+		clog.Warnf("actor system stopped with %d stuck actors!", stuck)
+		return
+	}
+
 	p.wg.Wait()
+	p.scheduler.stop(true)
 	clog.Info("actor system stopped!")
 }
 
@@ -95,12 +157,34 @@ func (p *System) GetChildActor(actorID, childID string) (*Actor, bool) {
 	return parentActor.child.GetActor(childID)
 }
 
-func (p *System) removeActor(actorID string) {
-	p.actorMap.Delete(actorID)
+// removeActor 移除actor(已被同id的新actor替换时不移除)
+func (p *System) removeActor(thisActor *Actor) {
+	value, found := p.actorMap.Load(thisActor.ActorID())
+	if found && value == thisActor {
+		p.actorMap.Delete(thisActor.ActorID())
+	}
 }
 
 // CreateActor 创建Actor
 func (p *System) CreateActor(id string, handler cfacade.IActorHandler) (cfacade.IActor, error) {
+	return p.createActor(id, handler, nil)
+}
+
+// CreateActorByFactory 通过factory创建Actor,监督者重启actor时通过factory重新创建handler
+func (p *System) CreateActorByFactory(id string, factory ActorFactory) (cfacade.IActor, error) {
+	if factory == nil {
+		return nil, ErrActorFactoryIsNil
+	}
+
+	handler := factory(id)
+	if handler == nil {
+		return nil, ErrActorFactoryIsNil
+	}
+
+	return p.createActor(id, handler, factory)
+}
+
+func (p *System) createActor(id string, handler cfacade.IActorHandler, factory ActorFactory) (cfacade.IActor, error) {
 	if strings.TrimSpace(id) == "" {
 		return nil, ErrActorIDIsNil
 	}
@@ -113,15 +197,32 @@ func (p *System) CreateActor(id string, handler cfacade.IActorHandler) (cfacade.
 	if err != nil {
 		return nil, err
 	}
+	thisActor.factory = factory
+
+	// add to map
+	if value, loaded := p.actorMap.LoadOrStore(id, thisActor); loaded {
+		p.wg.Done()
+		return value.(*Actor), nil
+	}
+
+	thisActor.start() // new actor is running!
 
-	p.actorMap.Store(id, thisActor) // add to map
-	go thisActor.run()              // new actor is running!
+	p.location.register(id)
 
 	return thisActor, nil
 }
 
 // Call 发送远程消息(不回复)
 func (p *System) Call(source, target, funcName string, arg interface{}) int32 {
+	return p.call(source, target, funcName, arg, false)
+}
+
+// CallPriority 发送控制消息,目标actor优先处理(如踢人、关闭通知)
+func (p *System) CallPriority(source, target, funcName string, arg interface{}) int32 {
+	return p.call(source, target, funcName, arg, true)
+}
+
+func (p *System) call(source, target, funcName string, arg interface{}, priority bool) int32 {
 	if target == "" {
 		clog.Warnf("[Call] Target path is nil. [source = %s, target = %s, funcName = %s]",
 			source,
@@ -140,6 +241,11 @@ func (p *System) Call(source, target, funcName string, arg interface{}) int32 {
 		return ccode.ActorFuncNameError
 	}
 
+	target, code := p.singletons.resolve(target)
+	if ccode.IsFail(code) {
+		return code
+	}
+
 	targetPath, err := cfacade.ToActorPath(target)
 	if err != nil {
 		clog.Warnf("[Call] Target path error. [source = %s, target = %s, funcName = %s, err = %v]",
@@ -156,6 +262,7 @@ func (p *System) Call(source, target, funcName string, arg interface{}) int32 {
 		clusterPacket.SourcePath = source
 		clusterPacket.TargetPath = target
 		clusterPacket.FuncName = funcName
+		clusterPacket.Priority = priority
 
 		if arg != nil {
 			argsBytes, err := p.app.Serializer().Marshal(arg)
@@ -185,10 +292,11 @@ func (p *System) Call(source, target, funcName string, arg interface{}) int32 {
 		remoteMsg.Target = target
 		remoteMsg.FuncName = funcName
 		remoteMsg.Args = arg
+		remoteMsg.Priority = priority
 
-		if !p.PostRemote(&remoteMsg) {
-			clog.Warnf("[Call] Post remote fail. [source = %s, target = %s, funcName = %s]", source, target, funcName)
-			return ccode.ActorCallFail
+		if code := p.postRemote(&remoteMsg); ccode.IsFail(code) {
+			clog.Warnf("[Call] Post remote fail. [source = %s, target = %s, funcName = %s, code = %d]", source, target, funcName, code)
+			return code
 		}
 	}
 
@@ -197,6 +305,16 @@ func (p *System) Call(source, target, funcName string, arg interface{}) int32 {
 
 // CallWait 发送远程消息(等待回复)
 func (p *System) CallWait(source, target, funcName string, arg interface{}, reply interface{}) int32 {
+	return p.callWait(source, target, funcName, arg, reply, nil)
+}
+
+// callWait chain为调用链中正在等待回复的actor path,目标actor已在调用链中时返回ActorCallDeadlock
+func (p *System) callWait(source, target, funcName string, arg interface{}, reply interface{}, chain []string) int32 {
+	target, code := p.singletons.resolve(target)
+	if ccode.IsFail(code) {
+		return code
+	}
+
 	sourcePath, err := cfacade.ToActorPath(source)
 	if err != nil {
 		clog.Warnf("[CallWait] Source path error. [source = %s, target = %s, funcName = %s, err = %v]",
@@ -237,9 +355,19 @@ func (p *System) CallWait(source, target, funcName string, arg interface{}, repl
 		return ccode.ActorFuncNameError
 	}
 
+	if isDeadlock(chain, p.fullPath(targetPath)) {
+		clog.Warnf("[CallWait] Deadlock detected. [cycle = %s -> %s, funcName = %s]",
+			strings.Join(chain, " -> "),
+			target,
+			funcName,
+		)
+		return ccode.ActorCallDeadlock
+	}
+
 	// forward to remote actor
 	if targetPath.NodeID != "" && targetPath.NodeID != sourcePath.NodeID {
 		clusterPacket := cproto.BuildClusterPacket(source, target, funcName)
+		clusterPacket.CallChain = chain
 
 		if arg != nil {
 			argsBytes, err := p.app.Serializer().Marshal(arg)
@@ -272,7 +400,8 @@ func (p *System) CallWait(source, target, funcName string, arg interface{}, repl
 		message.Target = target
 		message.FuncName = funcName
 		message.Args = arg
-		message.ChanResult = make(chan interface{})
+		message.ChanResult = make(chan interface{}, 1)
+		message.CallChain = chain
 
 		var result interface{}
 
@@ -282,14 +411,19 @@ func (p *System) CallWait(source, target, funcName string, arg interface{}, repl
 				return ccode.ActorChildIDNotFound
 			}
 
-			childActor.PostRemote(&message)
-			result = <-message.ChanResult
+			if code := childActor.pushRemote(&message); ccode.IsFail(code) {
+				return code
+			}
 		} else {
-			if !p.PostRemote(&message) {
-				clog.Warnf("[CallWait] Post remote fail. [source = %s, target = %s, funcName = %s]", source, target, funcName)
-				return ccode.ActorCallFail
+			if code := p.postRemote(&message); ccode.IsFail(code) {
+				clog.Warnf("[CallWait] Post remote fail. [source = %s, target = %s, funcName = %s, code = %d]", source, target, funcName, code)
+				return code
 			}
-			result = <-message.ChanResult
+		}
+
+		if result, code = p.waitResult(&message); ccode.IsFail(code) {
+			clog.Warnf("[CallWait] Wait result timeout. [source = %s, target = %s, funcName = %s, timeout = %s]", source, target, funcName, p.callTimeout)
+			return code
 		}
 
 		if result != nil {
@@ -330,47 +464,97 @@ func (p *System) CallWait(source, target, funcName string, arg interface{}, repl
 
 // PostRemote 提交远程消息
 func (p *System) PostRemote(m *cfacade.Message) bool {
+	return ccode.IsOK(p.postRemote(m))
+}
+
+func (p *System) postRemote(m *cfacade.Message) int32 {
 	if m == nil {
 		clog.Error("Message is nil.")
-		return false
+		return ccode.ActorCallFail
 	}
 
-	if targetActor, found := p.GetActor(m.TargetPath().ActorID); found {
-		if targetActor.state == WorkerState {
-			targetActor.PostRemote(m)
-		}
-		return true
+	if m.TargetPath().ActorID == locationActorID {
+		return p.location.onRemote(m)
 	}
 
-	clog.Warnf("[PostRemote] actor not found. [source = %s, target = %s -> %s]",
-		m.Source,
-		m.Target,
-		m.FuncName,
-	)
-	return false
+	targetActor, found := p.GetActor(m.TargetPath().ActorID)
+	// 停止期间不再接收其他节点的消息,节点内actor之间的消息仍可投递(先停止的actor可调用后停止的actor)
+	if (!found || m.IsCluster) && p.isStopping() {
+		p.deadLetter(m, RemoteName, ActorStoppedReason)
+		return ccode.ActorStopping
+	}
+
+	if !found {
+		targetActor, found = p.routers.route(m.TargetPath().ActorID, m)
+	}
+
+	if !found {
+		targetActor, found = p.passivation.activate(m.TargetPath().ActorID)
+	}
+
+	if !found {
+		clog.Warnf("[PostRemote] actor not found. [source = %s, target = %s -> %s]",
+			m.Source,
+			m.Target,
+			m.FuncName,
+		)
+		p.location.notifyNotFound(m)
+		p.deadLetter(m, RemoteName, ActorNotFoundReason)
+		return ccode.ActorCallFail
+	}
+
+	if targetActor.State() == StopState {
+		p.deadLetter(m, RemoteName, ActorStoppedReason)
+		return ccode.ActorStopping
+	}
+
+	// 邮箱已满时,邮箱已回复等待的调用方
+	return targetActor.pushRemote(m)
 }
 
 // PostLocal 提交本地消息
 func (p *System) PostLocal(m *cfacade.Message) bool {
+	return ccode.IsOK(p.postLocal(m))
+}
+
+func (p *System) postLocal(m *cfacade.Message) int32 {
 	if m == nil {
 		clog.Error("Message is nil.")
-		return false
+		return ccode.ActorCallFail
 	}
 
-	if targetActor, found := p.GetActor(m.TargetPath().ActorID); found {
-		if targetActor.state == WorkerState {
-			targetActor.PostLocal(m)
-		}
-		return true
+	// 停止期间不再接收客户端消息
+	if p.isStopping() {
+		p.deadLetter(m, LocalName, ActorStoppedReason)
+		return ccode.ActorStopping
+	}
+
+	targetActor, found := p.GetActor(m.TargetPath().ActorID)
+
+	if !found {
+		targetActor, found = p.routers.route(m.TargetPath().ActorID, m)
 	}
 
-	clog.Warnf("[PostLocal] actor not found. [source = %s, target = %s -> %s]",
-		m.Source,
-		m.Target,
-		m.FuncName,
-	)
+	if !found {
+		targetActor, found = p.passivation.activate(m.TargetPath().ActorID)
+	}
+
+	if !found {
+		clog.Warnf("[PostLocal] actor not found. [source = %s, target = %s -> %s]",
+			m.Source,
+			m.Target,
+			m.FuncName,
+		)
+		p.deadLetter(m, LocalName, ActorNotFoundReason)
+		return ccode.ActorCallFail
+	}
 
-	return false
+	if targetActor.State() == StopState {
+		p.deadLetter(m, LocalName, ActorStoppedReason)
+		return ccode.ActorStopping
+	}
+
+	return targetActor.pushLocal(m)
 }
 
 // PostEvent 提交事件
@@ -382,7 +566,7 @@ func (p *System) PostEvent(data cfacade.IEventData) {
 
 	p.actorMap.Range(func(key, value any) bool {
 		if thisActor, found := value.(*Actor); found {
-			if thisActor.state == WorkerState {
+			if thisActor.State() == WorkerState {
 				thisActor.event.Push(data)
 			}
 		}
@@ -390,15 +574,85 @@ func (p *System) PostEvent(data cfacade.IEventData) {
 	})
 }
 
+// RegisterEvent 注册可以跨节点发布的事件类型(指针类型),发布及接收的节点都需要在启动前注册
+func (p *System) RegisterEvent(events ...cfacade.IEventData) {
+	for _, data := range events {
+		if data != nil {
+			p.eventBus.register(data)
+		}
+	}
+}
+
+// PublishEvent 发布事件到集群中所有actor,nodeTypes为空则发布到所有节点,否则只发布到指定类型的节点
+func (p *System) PublishEvent(data cfacade.IEventData, nodeTypes ...string) {
+	if data == nil {
+		clog.Error("[PublishEvent] Event is nil.")
+		return
+	}
+
+	if p.app == nil || matchNodeType(p.app.NodeType(), nodeTypes) {
+		p.PostEvent(data)
+	}
+
+	p.eventBus.publish(data, nodeTypes...)
+}
+
+// Publish 发布事件给订阅了主题的actor,keys为空则主题为事件名,否则主题为 事件名:key
+func (p *System) Publish(data cfacade.IEventData, keys ...string) {
+	if data == nil {
+		clog.Error("[Publish] Event is nil.")
+		return
+	}
+
+	if len(keys) < 1 {
+		p.topics.publish(data.Name(), data)
+		return
+	}
+
+	for _, key := range keys {
+		p.topics.publish(topicKey(data.Name(), key), data)
+	}
+}
+
+// SubscriberCount 主题的订阅者数量
+func (p *System) SubscriberCount(name string, key ...string) int {
+	if len(key) > 0 {
+		return p.topics.count(topicKey(name, key[0]))
+	}
+
+	return p.topics.count(name)
+}
+
 func (p *System) SetLocalInvoke(fn cfacade.InvokeFunc) {
 	if fn != nil {
 		p.localInvokeFunc = fn
+		p.buildInvokeChain()
 	}
 }
 
 func (p *System) SetRemoteInvoke(fn cfacade.InvokeFunc) {
 	if fn != nil {
 		p.remoteInvokeFunc = fn
+		p.buildInvokeChain()
+	}
+}
+
+// Use 添加system中间件,对所有actor的local/remote调用生效.需要在创建actor前调用
+func (p *System) Use(middlewares ...cfacade.InvokeMiddleware) {
+	p.invokeChain.use(middlewares...)
+	p.buildInvokeChain()
+}
+
+// waitResult 等待本地actor的回复,超过callTimeout返回ActorCallTimeout(ChanResult有缓冲,超时后的回复不会阻塞目标actor)
+func (p *System) waitResult(m *cfacade.Message) (interface{}, int32) {
+	timer := time.NewTimer(p.callTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-m.ChanResult:
+		return result, ccode.OK
+	case <-timer.C:
+		return nil, ccode.ActorCallTimeout
 	}
 }
 
@@ -417,3 +671,151 @@ func (p *System) SetExecutionTimeout(t int64) {
 		p.executionTimeout = t
 	}
 }
+
+// SetSupervisorStrategy 设置顶层actor的监督策略
+func (p *System) SetSupervisorStrategy(strategy *SupervisorStrategy) {
+	if strategy != nil {
+		p.supervisorStrategy = strategy
+	}
+}
+
+// SetMailboxCapacity 设置新建actor的默认队列容量及溢出策略(local、remote、event)
+func (p *System) SetMailboxCapacity(capacity int32, policy OverflowPolicy, blockTimeout ...time.Duration) {
+	p.mailboxOverflow.SetCapacity(capacity, policy, blockTimeout...)
+}
+
+// OnDeadLetter 订阅死信
+func (p *System) OnDeadLetter(fn DeadLetterFunc) {
+	if fn != nil {
+		p.deadLetters.subscribe(fn)
+	}
+}
+
+// DeadLetterCount 获取指定原因的死信数量
+func (p *System) DeadLetterCount(reason DeadLetterReason) int64 {
+	return p.deadLetters.count(reason)
+}
+
+// Redeliver 重新投递死信
+// 等待回复的调用方在产生死信时已收到失败返回码,重新投递的消息不再回复
+func (p *System) Redeliver(letter *DeadLetter) int32 {
+	if letter == nil || letter.Message == nil {
+		return ccode.ActorCallFail
+	}
+
+	m := letter.Message
+	m.ChanResult = nil
+	m.ClusterReply = nil
+
+	if letter.MailName == LocalName {
+		return p.postLocal(m)
+	}
+
+	return p.postRemote(m)
+}
+
+// deadLetter 发布死信
+func (p *System) deadLetter(m *cfacade.Message, mailName string, reason DeadLetterReason) {
+	p.deadLetters.publish(&DeadLetter{
+		Message:  m,
+		Reason:   reason,
+		MailName: mailName,
+		Time:     ctime.Now().ToMillisecond(),
+	})
+}
+
+// RegisterFactory 注册actor factory
+// actorID匹配prefix的actor不存在时,收到消息将通过factory自动创建;开启钝化后,空闲的此类actor将被停止并移除
+func (p *System) RegisterFactory(prefix string, factory ActorFactory) {
+	if factory != nil {
+		p.passivation.register(prefix, factory)
+	}
+}
+
+// SetPassivateTTL 设置actor空闲钝化时间,小于等于0表示关闭
+func (p *System) SetPassivateTTL(ttl time.Duration) {
+	p.passivation.setTTL(ttl)
+}
+
+// SetRegistry 设置actor注册中心,创建顶层actor时注册,停止时注销
+// prefixes 需要注册的actorID前缀,为空则注册所有顶层actor
+func (p *System) SetRegistry(registry IActorRegistry, prefixes ...string) {
+	p.location.registry = registry
+	p.location.prefixes = prefixes
+}
+
+// Locate 获取actor所在的节点id
+func (p *System) Locate(actorID string) (string, bool) {
+	return p.location.resolve(actorID)
+}
+
+// CallByID 根据actorID发送远程消息(不回复),自动解析actor所在的节点
+func (p *System) CallByID(source, actorID, funcName string, arg interface{}) int32 {
+	return p.location.call(source, actorID, funcName, arg)
+}
+
+// CallWaitByID 根据actorID发送远程消息(等待回复),自动解析actor所在的节点
+func (p *System) CallWaitByID(source, actorID, funcName string, arg interface{}, reply interface{}) int32 {
+	return p.location.callWait(source, actorID, funcName, arg, reply, nil)
+}
+
+// RegisterSingleton 注册集群单例actor,同类型的所有节点都需要注册
+// 通过NewSingletonPath(nodeType, actorID)创建的逻辑路径调用单例actor
+func (p *System) RegisterSingleton(actorID string, factory SingletonFactory) {
+	if actorID != "" && factory != nil {
+		p.singletons.register(actorID, factory)
+	}
+}
+
+// SetSnapshotStore 设置actor状态快照存储,store为nil时使用默认的文件存储
+// interval 定时保存快照的间隔,<=0则只在actor停止时保存
+func (p *System) SetSnapshotStore(store ISnapshotStore, interval time.Duration) {
+	if store == nil {
+		store = NewFileStore(DefaultSnapshotDir)
+	}
+
+	p.snapshots.set(store, interval)
+}
+
+// eachActor 遍历所有actor(包括子actor)
+func (p *System) eachActor(fn func(thisActor *Actor)) {
+	p.actorMap.Range(func(key, value any) bool {
+		if thisActor, ok := value.(*Actor); ok {
+			fn(thisActor)
+			thisActor.child.Each(func(iActor cfacade.IActor) {
+				if childActor, ok := iActor.(*Actor); ok {
+					fn(childActor)
+				}
+			})
+		}
+		return true
+	})
+}
+
+// SetJournal 设置事件溯源actor的事件日志,journal为nil时使用默认的文件日志
+func (p *System) SetJournal(journal IJournal) {
+	if journal == nil {
+		journal = NewFileJournal(DefaultJournalDir)
+	}
+
+	p.journals.set(journal)
+}
+
+// SetTimerStore 设置持久化定时器的存储,未设置时使用默认的文件存储
+func (p *System) SetTimerStore(store ISnapshotStore) {
+	if store == nil {
+		store = NewFileStore(DefaultTimerDir)
+	}
+
+	p.timerStores.set(store)
+}
+
+// SetSchedulerMode 设置新建actor的默认调度方式(handler实现ISchedulerMode的actor除外)
+func (p *System) SetSchedulerMode(mode SchedulerMode) {
+	p.schedulerMode = mode
+}
+
+// SetSchedulerPool 设置PoolMode的worker数量(第一个PoolMode actor启动前有效)及每次调度最多处理的消息数
+func (p *System) SetSchedulerPool(workers, throughput int) {
+	p.scheduler.set(workers, throughput)
+}
@@ -0,0 +1,188 @@
+package cherryTestkit
+
+import (
+	"sync/atomic"
+
+	cfacade "github.com/cherry-game/cherry/facade"
+	cactor "github.com/cherry-game/cherry/net/actor"
+	cserializer "github.com/cherry-game/cherry/net/serializer"
+	cprofile "github.com/cherry-game/cherry/profile"
+)
+
+type (
+	// App 测试用的IApplication,不读取profile文件,不监听信号.
+	// 集群及发现服务使用所在Network的内存实现
+	App struct {
+		nodeID     string
+		nodeType   string
+		settings   cfacade.ProfileJSON
+		running    int32
+		dieChan    chan bool
+		components []cfacade.IComponent
+		onShutdown []func()
+		serializer cfacade.ISerializer
+		network    *Network
+		cluster    *Cluster
+		actor      *cactor.Component
+	}
+)
+
+func newApp(network *Network, nodeID, nodeType string) *App {
+	app := &App{
+		nodeID:     nodeID,
+		nodeType:   nodeType,
+		settings:   cprofile.Wrap(map[string]interface{}{}),
+		dieChan:    make(chan bool),
+		serializer: cserializer.NewJSON(),
+		network:    network,
+		actor:      cactor.New(),
+	}
+
+	app.cluster = &Cluster{
+		app:     app,
+		network: network,
+	}
+
+	app.Register(app.actor)
+
+	return app
+}
+
+func (p *App) NodeID() string {
+	return p.nodeID
+}
+
+func (p *App) NodeType() string {
+	return p.nodeType
+}
+
+func (p *App) Address() string {
+	return ""
+}
+
+func (p *App) RpcAddress() string {
+	return ""
+}
+
+func (p *App) Settings() cfacade.ProfileJSON {
+	return p.settings
+}
+
+func (p *App) Enabled() bool {
+	return true
+}
+
+func (p *App) Running() bool {
+	return atomic.LoadInt32(&p.running) > 0
+}
+
+func (p *App) DieChan() chan bool {
+	return p.dieChan
+}
+
+func (p *App) IsFrontend() bool {
+	return false
+}
+
+func (p *App) Register(components ...cfacade.IComponent) {
+	for _, component := range components {
+		component.Set(p)
+		p.components = append(p.components, component)
+	}
+}
+
+func (p *App) Find(name string) cfacade.IComponent {
+	for _, component := range p.components {
+		if component.Name() == name {
+			return component
+		}
+	}
+
+	return nil
+}
+
+func (p *App) Remove(name string) cfacade.IComponent {
+	for i, component := range p.components {
+		if component.Name() == name {
+			p.components = append(p.components[:i], p.components[i+1:]...)
+			return component
+		}
+	}
+
+	return nil
+}
+
+func (p *App) All() []cfacade.IComponent {
+	return p.components
+}
+
+func (p *App) OnShutdown(fn ...func()) {
+	p.onShutdown = append(p.onShutdown, fn...)
+}
+
+// Startup 初始化所有组件并加入Network(不阻塞)
+func (p *App) Startup() {
+	if !atomic.CompareAndSwapInt32(&p.running, 0, 1) {
+		return
+	}
+
+	p.network.join(p)
+
+	for _, component := range p.components {
+		component.Init()
+	}
+
+	for _, component := range p.components {
+		component.OnAfterInit()
+	}
+}
+
+// Shutdown 停止所有组件并离开Network
+func (p *App) Shutdown() {
+	if !atomic.CompareAndSwapInt32(&p.running, 1, 0) {
+		return
+	}
+
+	for _, fn := range p.onShutdown {
+		fn()
+	}
+
+	for i := len(p.components) - 1; i >= 0; i-- {
+		p.components[i].OnBeforeStop()
+	}
+
+	for i := len(p.components) - 1; i >= 0; i-- {
+		p.components[i].OnStop()
+	}
+
+	p.network.leave(p)
+	close(p.dieChan)
+}
+
+// SetSerializer 设置序列化,需要在Startup()前调用
+func (p *App) SetSerializer(serializer cfacade.ISerializer) {
+	if serializer != nil {
+		p.serializer = serializer
+	}
+}
+
+func (p *App) Serializer() cfacade.ISerializer {
+	return p.serializer
+}
+
+func (p *App) Discovery() cfacade.IDiscovery {
+	return p.network.discovery
+}
+
+func (p *App) Cluster() cfacade.ICluster {
+	return p.cluster
+}
+
+func (p *App) ActorSystem() cfacade.IActorSystem {
+	return p.actor
+}
+
+// System actor系统
+func (p *App) System() *cactor.System {
+	return p.actor.System
+}
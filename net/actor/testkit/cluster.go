@@ -0,0 +1,191 @@
+package cherryTestkit
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	ccode "github.com/cherry-game/cherry/code"
+	cerr "github.com/cherry-game/cherry/error"
+	cfacade "github.com/cherry-game/cherry/facade"
+	cdiscovery "github.com/cherry-game/cherry/net/discovery"
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+const (
+	defaultRequestTimeout = 3 * time.Second
+)
+
+type (
+	// Network 进程内的集群网络,同一Network中的App通过内存互相投递集群消息
+	Network struct {
+		sync.RWMutex
+		apps      map[string]*App // key:nodeID
+		discovery *cdiscovery.DiscoveryDefault
+	}
+
+	// Cluster 基于Network的ICluster实现,集群包经过protobuf编解码后投递到目标节点的actor系统
+	Cluster struct {
+		app     *App
+		network *Network
+	}
+
+	// reply 接收RequestRemote的回复
+	reply chan []byte
+)
+
+// NewNetwork 创建进程内的集群网络
+func NewNetwork() *Network {
+	return &Network{
+		apps:      make(map[string]*App),
+		discovery: &cdiscovery.DiscoveryDefault{},
+	}
+}
+
+// NewApp 创建节点并启动
+func (p *Network) NewApp(nodeID, nodeType string) *App {
+	app := newApp(p, nodeID, nodeType)
+	app.Startup()
+
+	return app
+}
+
+// App 根据nodeID获取节点
+func (p *Network) App(nodeID string) (*App, bool) {
+	p.RLock()
+	defer p.RUnlock()
+
+	app, found := p.apps[nodeID]
+	return app, found
+}
+
+// Discovery 所有节点共享的发现服务
+func (p *Network) Discovery() cfacade.IDiscovery {
+	return p.discovery
+}
+
+func (p *Network) join(app *App) {
+	p.Lock()
+	p.apps[app.NodeID()] = app
+	p.Unlock()
+
+	p.discovery.AddMember(&cproto.Member{
+		NodeID:   app.NodeID(),
+		NodeType: app.NodeType(),
+		Settings: make(map[string]string),
+	})
+}
+
+func (p *Network) leave(app *App) {
+	p.Lock()
+	delete(p.apps, app.NodeID())
+	p.Unlock()
+
+	p.discovery.RemoveMember(app.NodeID())
+}
+
+// target 获取运行中的目标节点,并复制集群包(模拟网络传输)
+func (p *Cluster) target(nodeID string, packet *cproto.ClusterPacket) (*App, *cproto.ClusterPacket, error) {
+	defer packet.Recycle()
+
+	app, found := p.network.App(nodeID)
+	if !found || !app.Running() {
+		return nil, nil, cerr.Errorf("nodeID = %s not found.", nodeID)
+	}
+
+	data, err := proto.Marshal(packet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	copied := &cproto.ClusterPacket{}
+	if err = proto.Unmarshal(data, copied); err != nil {
+		return nil, nil, err
+	}
+
+	return app, copied, nil
+}
+
+func toMessage(packet *cproto.ClusterPacket) *cfacade.Message {
+	message := cfacade.GetMessage()
+	message.BuildTime = packet.BuildTime
+	message.Source = packet.SourcePath
+	message.Target = packet.TargetPath
+	message.FuncName = packet.FuncName
+	message.IsCluster = true
+	message.Session = packet.Session
+	message.Priority = packet.Priority
+	message.CallChain = packet.CallChain
+	if packet.ArgBytes != nil {
+		message.Args = packet.ArgBytes
+	}
+
+	return &message
+}
+
+func (p *Cluster) Init() {
+}
+
+func (p *Cluster) PublishLocal(nodeID string, packet *cproto.ClusterPacket) error {
+	app, packet, err := p.target(nodeID, packet)
+	if err != nil {
+		return err
+	}
+
+	app.ActorSystem().PostLocal(toMessage(packet))
+	return nil
+}
+
+func (p *Cluster) PublishRemote(nodeID string, packet *cproto.ClusterPacket) error {
+	app, packet, err := p.target(nodeID, packet)
+	if err != nil {
+		return err
+	}
+
+	app.ActorSystem().PostRemote(toMessage(packet))
+	return nil
+}
+
+func (p *Cluster) RequestRemote(nodeID string, packet *cproto.ClusterPacket, timeout ...time.Duration) cproto.Response {
+	app, packet, err := p.target(nodeID, packet)
+	if err != nil {
+		return cproto.Response{Code: ccode.DiscoveryNotFoundNode}
+	}
+
+	d := defaultRequestTimeout
+	if len(timeout) > 0 {
+		d = timeout[0]
+	}
+
+	replyChan := make(reply, 1)
+	message := toMessage(packet)
+	message.ClusterReply = replyChan
+
+	if !app.ActorSystem().PostRemote(message) {
+		return cproto.Response{Code: ccode.ActorCallFail}
+	}
+
+	select {
+	case data := <-replyChan:
+		rsp := &cproto.Response{}
+		if err = proto.Unmarshal(data, rsp); err != nil {
+			return cproto.Response{Code: ccode.RPCUnmarshalError}
+		}
+		return cproto.Response{Code: rsp.Code, Data: rsp.Data}
+	case <-time.After(d):
+		return cproto.Response{Code: ccode.RPCNetError}
+	}
+}
+
+func (p *Cluster) Stop() {
+}
+
+func (p reply) Respond(data []byte) error {
+	select {
+	case p <- data:
+		return nil
+	default:
+		return cerr.Error("Duplicate respond.")
+	}
+}
@@ -0,0 +1,437 @@
+package cherryTestkit
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	ccode "github.com/cherry-game/cherry/code"
+	ctime "github.com/cherry-game/cherry/extend/time"
+	cfacade "github.com/cherry-game/cherry/facade"
+	cactor "github.com/cherry-game/cherry/net/actor"
+	"github.com/cherry-game/cherry/net/parser/pomelo"
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+const (
+	DefaultNodeID   = "testkit-1"    // Kit默认的节点id
+	DefaultNodeType = "testkit"      // Kit默认的节点类型
+	AgentActorID    = "testkitAgent" // 模拟网关agent的actor id
+	SourceActorID   = "testkit"      // Kit发送remote消息时的来源actor id
+	defaultTimeout  = 3 * time.Second
+)
+
+type (
+	// Kit 不依赖profile文件及Startup()的actor测试工具
+	//
+	// 创建单节点的App(可通过Network()添加更多节点),用模拟的agent actor接收handler回复客户端的
+	// response/push/kick,并将全局时钟替换为手动时钟.同一时间只能有一个Kit(全局时钟)
+	Kit struct {
+		*App
+		tb       testing.TB
+		network  *Network
+		clock    *ctime.ManualClock
+		timeout  time.Duration
+		mid      uint32
+		sid      int64
+		recorder *recorder
+		recordID int32
+	}
+
+	// recorder 记录agent收到的消息及actor收到的事件
+	recorder struct {
+		sync.Mutex
+		updated    chan struct{} // 有新记录时关闭
+		responses  []*cproto.PomeloResponse
+		pushes     []*cproto.PomeloPush
+		kicks      []*cproto.PomeloKick
+		broadcasts []*cproto.PomeloBroadcastPush
+		events     []cfacade.IEventData
+	}
+
+	// agentActor 模拟网关的agent actor
+	agentActor struct {
+		cactor.Base
+		recorder *recorder
+	}
+
+	// eventActor 记录指定名称的事件
+	eventActor struct {
+		cactor.Base
+		names    []string
+		recorder *recorder
+	}
+)
+
+// New 创建Kit,测试结束时自动停止并恢复全局时钟
+func New(tb testing.TB) *Kit {
+	kit := &Kit{
+		tb:       tb,
+		network:  NewNetwork(),
+		clock:    ctime.NewManualClock(time.Time{}),
+		timeout:  defaultTimeout,
+		recorder: newRecorder(),
+	}
+
+	ctime.SetClock(kit.clock)
+
+	kit.App = kit.network.NewApp(DefaultNodeID, DefaultNodeType)
+	kit.CreateActor(AgentActorID, &agentActor{recorder: kit.recorder})
+
+	tb.Cleanup(kit.Stop)
+
+	return kit
+}
+
+// Stop 停止所有节点并恢复系统时钟
+func (p *Kit) Stop() {
+	p.network.RLock()
+	apps := make([]*App, 0, len(p.network.apps))
+	for _, app := range p.network.apps {
+		apps = append(apps, app)
+	}
+	p.network.RUnlock()
+
+	for _, app := range apps {
+		app.Shutdown()
+	}
+
+	ctime.SetClock(nil)
+}
+
+// Network 所在的集群网络
+func (p *Kit) Network() *Network {
+	return p.network
+}
+
+// Clock 手动时钟
+func (p *Kit) Clock() *ctime.ManualClock {
+	return p.clock
+}
+
+// Advance 时间前进d,同步触发到期的定时器
+func (p *Kit) Advance(d time.Duration) {
+	p.clock.Advance(d)
+}
+
+// SetTimeout 设置Await系列函数的等待时间
+func (p *Kit) SetTimeout(d time.Duration) {
+	if d > 0 {
+		p.timeout = d
+	}
+}
+
+// Path 本节点的actor path
+func (p *Kit) Path(actorID string, childID ...string) string {
+	if len(childID) > 0 {
+		return cfacade.NewChildPath(p.NodeID(), actorID, childID[0])
+	}
+
+	return cfacade.NewPath(p.NodeID(), actorID)
+}
+
+// CreateActor 在Kit的节点创建actor,并等待OnInit()执行完成
+func (p *Kit) CreateActor(id string, handler cfacade.IActorHandler) cfacade.IActor {
+	p.tb.Helper()
+
+	return p.CreateActorOn(p.App, id, handler)
+}
+
+// CreateActorOn 在app节点创建actor,并等待OnInit()执行完成
+func (p *Kit) CreateActorOn(app *App, id string, handler cfacade.IActorHandler) cfacade.IActor {
+	p.tb.Helper()
+
+	iActor, err := app.System().CreateActor(id, handler)
+	if err != nil {
+		p.tb.Fatalf("create actor fail. [id = %s, err = %v]", id, err)
+	}
+
+	thisActor, ok := iActor.(*cactor.Actor)
+	if !ok {
+		return iActor
+	}
+
+	ready := make(chan struct{})
+	thisActor.Go(func() {}, func() {
+		close(ready)
+	})
+
+	select {
+	case <-ready:
+	case <-time.After(p.timeout):
+		p.tb.Fatalf("actor init timeout. [id = %s]", id)
+	}
+
+	return iActor
+}
+
+// NewSession 创建模拟客户端的session,回复及推送由Kit的agent actor接收
+func (p *Kit) NewSession(uid int64) *cproto.Session {
+	return &cproto.Session{
+		Sid:       strconv.FormatInt(atomic.AddInt64(&p.sid, 1), 10),
+		Uid:       uid,
+		AgentPath: p.Path(AgentActorID),
+		Data:      make(map[string]string),
+	}
+}
+
+// Request 模拟客户端请求,向target发送local消息,返回消息id(用于AwaitResponse)
+func (p *Kit) Request(session *cproto.Session, target, funcName string, arg interface{}) uint32 {
+	p.tb.Helper()
+
+	var argBytes []byte
+	if arg != nil {
+		var err error
+		if argBytes, err = p.Serializer().Marshal(arg); err != nil {
+			p.tb.Fatalf("marshal arg fail. [funcName = %s, err = %v]", funcName, err)
+		}
+	}
+
+	mid := atomic.AddUint32(&p.mid, 1)
+	session = proto.Clone(session).(*cproto.Session)
+	session.Mid = mid
+
+	message := cfacade.GetMessage()
+	message.Source = session.AgentPath
+	message.Target = target
+	message.FuncName = funcName
+	message.Session = session
+	message.Args = argBytes
+
+	if !p.System().PostLocal(&message) {
+		p.tb.Fatalf("post local fail. [target = %s, funcName = %s]", target, funcName)
+	}
+
+	return mid
+}
+
+// Call 向target发送remote消息(不回复)
+func (p *Kit) Call(target, funcName string, arg interface{}) int32 {
+	return p.System().Call(p.Path(SourceActorID), target, funcName, arg)
+}
+
+// CallWait 向target发送remote消息并等待回复
+func (p *Kit) CallWait(target, funcName string, arg, reply interface{}) int32 {
+	return p.System().CallWait(p.Path(SourceActorID), target, funcName, arg, reply)
+}
+
+// PostEvent 发布事件
+func (p *Kit) PostEvent(data cfacade.IEventData) {
+	p.System().PostEvent(data)
+}
+
+// AwaitResponse 等待消息id为mid的回复,返回状态码.reply不为nil时解析回复数据
+func (p *Kit) AwaitResponse(mid uint32, reply interface{}) int32 {
+	p.tb.Helper()
+
+	var rsp *cproto.PomeloResponse
+	p.await(pomelo.ResponseFuncName, func() bool {
+		for _, item := range p.recorder.responses {
+			if item.Mid == mid {
+				rsp = item
+				return true
+			}
+		}
+		return false
+	})
+
+	if ccode.IsOK(rsp.Code) && reply != nil {
+		if err := p.Serializer().Unmarshal(rsp.Data, reply); err != nil {
+			p.tb.Fatalf("unmarshal response fail. [mid = %d, err = %v]", mid, err)
+		}
+	}
+
+	return rsp.Code
+}
+
+// AwaitPush 等待推送给session的route消息(第n条,从1开始),v不为nil时解析推送数据
+func (p *Kit) AwaitPush(session *cproto.Session, route string, v interface{}, n ...int) {
+	p.tb.Helper()
+
+	index := 1
+	if len(n) > 0 {
+		index = n[0]
+	}
+
+	var push *cproto.PomeloPush
+	p.await("push "+route, func() bool {
+		count := 0
+		for _, item := range p.recorder.pushes {
+			if item.Sid == session.Sid && item.Route == route {
+				if count++; count == index {
+					push = item
+					return true
+				}
+			}
+		}
+		return false
+	})
+
+	if v != nil {
+		if err := p.Serializer().Unmarshal(push.Data, v); err != nil {
+			p.tb.Fatalf("unmarshal push fail. [route = %s, err = %v]", route, err)
+		}
+	}
+}
+
+// AwaitKick 等待session被踢下线
+func (p *Kit) AwaitKick(session *cproto.Session) *cproto.PomeloKick {
+	p.tb.Helper()
+
+	var kick *cproto.PomeloKick
+	p.await(pomelo.KickFuncName, func() bool {
+		for _, item := range p.recorder.kicks {
+			if item.Sid == session.Sid || (session.Uid > 0 && item.Uid == session.Uid) {
+				kick = item
+				return true
+			}
+		}
+		return false
+	})
+
+	return kick
+}
+
+// RecordEvents 记录指定名称的事件,之后可通过AwaitEvent()获取
+func (p *Kit) RecordEvents(names ...string) {
+	id := atomic.AddInt32(&p.recordID, 1)
+	p.CreateActor("testkitEvents"+strconv.Itoa(int(id)), &eventActor{
+		names:    names,
+		recorder: p.recorder,
+	})
+}
+
+// AwaitEvent 等待已记录的名称为name的事件(第n条,从1开始)
+func (p *Kit) AwaitEvent(name string, n ...int) cfacade.IEventData {
+	p.tb.Helper()
+
+	index := 1
+	if len(n) > 0 {
+		index = n[0]
+	}
+
+	var data cfacade.IEventData
+	p.await("event "+name, func() bool {
+		count := 0
+		for _, item := range p.recorder.events {
+			if item.Name() == name {
+				if count++; count == index {
+					data = item
+					return true
+				}
+			}
+		}
+		return false
+	})
+
+	return data
+}
+
+// Responses 已收到的所有回复
+func (p *Kit) Responses() []*cproto.PomeloResponse {
+	p.recorder.Lock()
+	defer p.recorder.Unlock()
+
+	return append([]*cproto.PomeloResponse(nil), p.recorder.responses...)
+}
+
+// Pushes 已收到的所有推送
+func (p *Kit) Pushes() []*cproto.PomeloPush {
+	p.recorder.Lock()
+	defer p.recorder.Unlock()
+
+	return append([]*cproto.PomeloPush(nil), p.recorder.pushes...)
+}
+
+// Broadcasts 已收到的所有广播
+func (p *Kit) Broadcasts() []*cproto.PomeloBroadcastPush {
+	p.recorder.Lock()
+	defer p.recorder.Unlock()
+
+	return append([]*cproto.PomeloBroadcastPush(nil), p.recorder.broadcasts...)
+}
+
+// Events 已记录的所有事件
+func (p *Kit) Events() []cfacade.IEventData {
+	p.recorder.Lock()
+	defer p.recorder.Unlock()
+
+	return append([]cfacade.IEventData(nil), p.recorder.events...)
+}
+
+// await 等待match返回true(持有recorder锁时调用match),超时则测试失败
+func (p *Kit) await(what string, match func() bool) {
+	p.tb.Helper()
+
+	timeout := time.After(p.timeout)
+	for {
+		p.recorder.Lock()
+		found := match()
+		updated := p.recorder.updated
+		p.recorder.Unlock()
+
+		if found {
+			return
+		}
+
+		select {
+		case <-updated:
+		case <-timeout:
+			p.tb.Fatalf("await %s timeout.", what)
+			return
+		}
+	}
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		updated: make(chan struct{}),
+	}
+}
+
+func (p *recorder) record(fn func()) {
+	p.Lock()
+	defer p.Unlock()
+
+	fn()
+	close(p.updated)
+	p.updated = make(chan struct{})
+}
+
+func (p *agentActor) OnInit() {
+	p.Remote().Register(pomelo.ResponseFuncName, func(rsp *cproto.PomeloResponse) {
+		p.recorder.record(func() {
+			p.recorder.responses = append(p.recorder.responses, rsp)
+		})
+	})
+
+	p.Remote().Register(pomelo.PushFuncName, func(rsp *cproto.PomeloPush) {
+		p.recorder.record(func() {
+			p.recorder.pushes = append(p.recorder.pushes, rsp)
+		})
+	})
+
+	p.Remote().Register(pomelo.KickFuncName, func(rsp *cproto.PomeloKick) {
+		p.recorder.record(func() {
+			p.recorder.kicks = append(p.recorder.kicks, rsp)
+		})
+	})
+
+	p.Remote().Register(pomelo.BroadcastName, func(rsp *cproto.PomeloBroadcastPush) {
+		p.recorder.record(func() {
+			p.recorder.broadcasts = append(p.recorder.broadcasts, rsp)
+		})
+	})
+}
+
+func (p *eventActor) OnInit() {
+	p.Event().Registers(p.names, func(data cfacade.IEventData) {
+		p.recorder.record(func() {
+			p.recorder.events = append(p.recorder.events, data)
+		})
+	})
+}
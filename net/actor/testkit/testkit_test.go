@@ -0,0 +1,480 @@
+package cherryTestkit
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ccode "github.com/cherry-game/cherry/code"
+	creflect "github.com/cherry-game/cherry/extend/reflect"
+	cfacade "github.com/cherry-game/cherry/facade"
+	cactor "github.com/cherry-game/cherry/net/actor"
+	"github.com/cherry-game/cherry/net/parser/pomelo"
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+type (
+	loginReq struct {
+		Name string `json:"name"`
+	}
+
+	loginRsp struct {
+		Level int32 `json:"level"`
+	}
+
+	addReq struct {
+		A, B int32
+	}
+
+	addRsp struct {
+		Sum int32
+	}
+
+	nodeRsp struct {
+		NodeID string
+	}
+
+	loginEvent struct {
+		uid int64
+	}
+
+	playerActor struct {
+		pomelo.ActorBase
+	}
+
+	mathActor struct {
+		cactor.Base
+	}
+
+	timerActor struct {
+		cactor.Base
+		fired chan struct{}
+	}
+
+	mapRegistry struct {
+		sync.Map
+	}
+
+	singletonActor struct {
+		cactor.Base
+		active  *int32        // 本节点运行中的实例数量
+		overlap *int32        // 是否同时存在多个实例
+		release chan struct{} // OnStop阻塞直到关闭
+	}
+)
+
+func (p *singletonActor) OnInit() {
+	if atomic.AddInt32(p.active, 1) > 1 {
+		atomic.StoreInt32(p.overlap, 1)
+	}
+
+	p.Remote().Register("node", func() (*nodeRsp, int32) {
+		return &nodeRsp{NodeID: p.App().NodeID()}, ccode.OK
+	})
+}
+
+func (p *singletonActor) OnStop() {
+	if p.release != nil {
+		<-p.release
+	}
+	atomic.AddInt32(p.active, -1)
+}
+
+func newSingletonApp(network *Network, nodeID string, handler func() *singletonActor) *App {
+	app := newApp(network, nodeID, "game")
+	app.System().RegisterSingleton("leader", func() cfacade.IActorHandler {
+		return handler()
+	})
+	app.Startup()
+
+	return app
+}
+
+func waitActor(t *testing.T, app *App, actorID string) {
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, found := app.System().GetActor(actorID); found {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("actor not found. [nodeID = %s, actorID = %s]", app.NodeID(), actorID)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func (p *mapRegistry) Register(actorID, nodeID string) {
+	p.Store(actorID, nodeID)
+}
+
+func (p *mapRegistry) Unregister(actorID, _ string) {
+	p.Delete(actorID)
+}
+
+func (p *mapRegistry) Lookup(actorID string) (string, bool) {
+	value, found := p.Load(actorID)
+	if !found {
+		return "", false
+	}
+	return value.(string), true
+}
+
+func (e *loginEvent) Name() string {
+	return "login"
+}
+
+func (e *loginEvent) UniqueID() int64 {
+	return e.uid
+}
+
+func (p *playerActor) OnInit() {
+	p.Local().Register("login", func(session *cproto.Session, req *loginReq) {
+		p.Response(session, &loginRsp{Level: 10})
+		p.PostEvent(&loginEvent{uid: session.Uid})
+
+		p.Timer().AddOnce(time.Hour, func() {
+			p.Push(session, "onReward", &loginRsp{Level: 11})
+		})
+	})
+}
+
+func (p *mathActor) OnInit() {
+	p.Remote().Register("add", func(req *addReq) (*addRsp, int32) {
+		return &addRsp{Sum: req.A + req.B}, ccode.OK
+	})
+
+	p.Remote().Register("double", func(n int32) (int32, int32) {
+		return n * 2, ccode.OK
+	})
+
+	p.Remote().Register("div", func(req *addReq) (*addRsp, int32) {
+		return &addRsp{Sum: req.A / req.B}, ccode.OK
+	})
+}
+
+func (p *timerActor) OnInit() {
+	p.Timer().AddOnce(2*time.Second, func() {
+		close(p.fired)
+	})
+}
+
+func TestKit(t *testing.T) {
+	kit := New(t)
+	kit.RecordEvents("login")
+	kit.CreateActor("player", &playerActor{})
+
+	session := kit.NewSession(1001)
+	mid := kit.Request(session, kit.Path("player"), "login", &loginReq{Name: "cherry"})
+
+	rsp := &loginRsp{}
+	if code := kit.AwaitResponse(mid, rsp); code != ccode.OK || rsp.Level != 10 {
+		t.Fatalf("code = %d, rsp = %+v", code, rsp)
+	}
+
+	if event := kit.AwaitEvent("login").(*loginEvent); event.uid != 1001 {
+		t.Fatalf("event = %+v", event)
+	}
+
+	kit.Advance(time.Hour)
+
+	push := &loginRsp{}
+	kit.AwaitPush(session, "onReward", push)
+	if push.Level != 11 {
+		t.Fatalf("push = %+v", push)
+	}
+}
+
+func TestKitCluster(t *testing.T) {
+	kit := New(t)
+
+	game := kit.Network().NewApp("game-1", "game")
+	kit.CreateActorOn(game, "math", &mathActor{})
+
+	rsp := &addRsp{}
+	code := kit.CallWait(cfacade.NewPath("game-1", "math"), "add", &addReq{A: 1, B: 2}, rsp)
+	if code != ccode.OK || rsp.Sum != 3 {
+		t.Fatalf("code = %d, rsp = %+v", code, rsp)
+	}
+
+	var doubled int32
+	code = kit.CallWait(cfacade.NewPath("game-1", "math"), "double", int32(21), &doubled)
+	if code != ccode.OK || doubled != 42 {
+		t.Fatalf("code = %d, doubled = %d", code, doubled)
+	}
+
+	game.Shutdown()
+
+	code = kit.CallWait(cfacade.NewPath("game-1", "math"), "add", &addReq{A: 1, B: 2}, rsp)
+	if code != ccode.DiscoveryNotFoundNode {
+		t.Fatalf("code = %d", code)
+	}
+}
+
+func TestKitStopRestoreTimer(t *testing.T) {
+	t.Run("advance", func(t *testing.T) {
+		kit := New(t)
+		kit.Advance(time.Hour)
+	})
+
+	// Kit停止后恢复系统时钟,全局时间轮不能停留在手动时钟的时间
+	system := cactor.NewSystem()
+	defer system.Stop()
+
+	handler := &timerActor{fired: make(chan struct{})}
+	system.CreateActor("timer", handler)
+
+	select {
+	case <-handler.fired:
+		t.Fatal("timer fired immediately after kit stopped")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestKitLocationNotFound(t *testing.T) {
+	kit := New(t)
+
+	registry := &mapRegistry{}
+	game1 := kit.Network().NewApp("game-1", "game")
+	game2 := kit.Network().NewApp("game-2", "game")
+	game1.System().SetRegistry(registry, "player_")
+	game2.System().SetRegistry(registry, "player_")
+
+	// 缓存的位置已过期,actor已不在game-2
+	registry.Register("player_1", "game-2")
+	if nodeID, _ := game1.System().Locate("player_1"); nodeID != "game-2" {
+		t.Fatalf("nodeID = %s", nodeID)
+	}
+	registry.Register("player_1", "game-3")
+
+	source := cfacade.NewPath("game-1", "caller")
+	if code := game1.System().CallByID(source, "player_1", "login", nil); code != ccode.OK {
+		t.Fatalf("code = %d", code)
+	}
+
+	if nodeID, _ := game1.System().Locate("player_1"); nodeID != "game-3" {
+		t.Fatalf("stale location not invalidated. nodeID = %s", nodeID)
+	}
+}
+
+func TestKitSingleton(t *testing.T) {
+	kit := New(t)
+
+	var active1, active2, overlap int32
+	game1 := newSingletonApp(kit.Network(), "game-1", func() *singletonActor {
+		return &singletonActor{active: &active1, overlap: &overlap}
+	})
+	game2 := newSingletonApp(kit.Network(), "game-2", func() *singletonActor {
+		return &singletonActor{active: &active2, overlap: &overlap}
+	})
+
+	// nodeID最小的节点为owner
+	waitActor(t, game1, "leader")
+	if _, found := game2.System().GetActor("leader"); found {
+		t.Fatal("singleton created on non-owner node")
+	}
+
+	// owner节点离开后由新的owner节点接管
+	game1.Shutdown()
+	waitActor(t, game2, "leader")
+
+	if atomic.LoadInt32(&overlap) != 0 {
+		t.Fatal("singleton activated twice")
+	}
+}
+
+func TestKitSingletonNoDoubleActivation(t *testing.T) {
+	kit := New(t)
+
+	var active, overlap int32
+	release := make(chan struct{})
+	game2 := newSingletonApp(kit.Network(), "game-2", func() *singletonActor {
+		return &singletonActor{active: &active, overlap: &overlap, release: release}
+	})
+	waitActor(t, game2, "leader")
+
+	// game-1加入后成为owner,game-2的实例开始停止(OnStop阻塞)
+	member := &cproto.Member{NodeID: "game-1", NodeType: "game", Settings: map[string]string{}}
+	kit.Network().Discovery().AddMember(member)
+
+	// 旧实例停止前game-2重新成为owner,不能创建新实例
+	kit.Network().Discovery().RemoveMember("game-1")
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if thisActor, found := game2.System().GetActor("leader"); found && thisActor.State() == cactor.WorkerState {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("singleton not reactivated")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if atomic.LoadInt32(&overlap) != 0 {
+		t.Fatal("singleton activated twice")
+	}
+}
+
+func TestKitPanicReplyAfterMiddleware(t *testing.T) {
+	kit := New(t)
+
+	var chainDone int32
+	game := newApp(kit.Network(), "game-1", "game")
+	game.System().Use(func(next cfacade.InvokeFunc) cfacade.InvokeFunc {
+		return func(app cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade.Message) {
+			defer func() {
+				time.Sleep(20 * time.Millisecond)
+				atomic.StoreInt32(&chainDone, 1)
+			}()
+			next(app, fi, m)
+		}
+	})
+	game.Startup()
+	kit.CreateActorOn(game, "math", &mathActor{})
+
+	rsp := &addRsp{}
+	code := kit.CallWait(cfacade.NewPath("game-1", "math"), "div", &addReq{A: 1, B: 0}, rsp)
+	if code != ccode.RPCRemoteExecuteError {
+		t.Fatalf("code = %d", code)
+	}
+
+	// panic经过中间件链之后才回复调用方
+	if atomic.LoadInt32(&chainDone) != 1 {
+		t.Fatal("replied before the middleware chain ran")
+	}
+}
+
+func TestKitMiddlewareAbort(t *testing.T) {
+	kit := New(t)
+
+	const denyCode int32 = 1001
+
+	var args interface{}
+
+	game := newApp(kit.Network(), "game-1", "game")
+	game.System().Use(func(next cfacade.InvokeFunc) cfacade.InvokeFunc {
+		return func(app cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade.Message) {
+			args = m.Args
+			m.Abort(denyCode)
+			next(app, fi, m)
+		}
+	})
+	game.Startup()
+	kit.CreateActorOn(game, "math", &mathActor{})
+
+	begin := time.Now()
+	rsp := &addRsp{}
+	code := kit.CallWait(cfacade.NewPath("game-1", "math"), "add", &addReq{A: 1, B: 2}, rsp)
+	if code != denyCode {
+		t.Fatalf("code = %d", code)
+	}
+
+	if time.Since(begin) > time.Second {
+		t.Fatal("abort reply waited for timeout")
+	}
+
+	// 中间件读取已解码的参数
+	if req, ok := args.(*addReq); !ok || req.A != 1 || req.B != 2 {
+		t.Fatalf("args = %+v", args)
+	}
+
+	if rsp.Sum != 0 {
+		t.Fatal("function invoked after abort")
+	}
+}
+
+func TestKitMiddlewareRecoverReply(t *testing.T) {
+	kit := New(t)
+
+	game := newApp(kit.Network(), "game-1", "game")
+	game.System().Use(func(next cfacade.InvokeFunc) cfacade.InvokeFunc {
+		return func(app cfacade.IApplication, fi *creflect.FuncInfo, m *cfacade.Message) {
+			defer func() {
+				if rev := recover(); rev != nil {
+					m.Reply(ccode.ActorCallFail)
+				}
+			}()
+			next(app, fi, m)
+		}
+	})
+	game.Startup()
+	kit.CreateActorOn(game, "math", &mathActor{})
+
+	code := kit.CallWait(cfacade.NewPath("game-1", "math"), "div", &addReq{A: 1, B: 0}, &addRsp{})
+	if code != ccode.ActorCallFail {
+		t.Fatalf("code = %d", code)
+	}
+}
+
+// waitActorStopped 等待actor从节点移除
+func waitActorStopped(t *testing.T, app *App, actorID string) {
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, found := app.System().GetActor(actorID); !found {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("actor not stopped. [nodeID = %s, actorID = %s]", app.NodeID(), actorID)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// callSingletonNode 通过单例逻辑路径调用,返回处理消息的节点
+func callSingletonNode(t *testing.T, kit *Kit) string {
+	rsp := &nodeRsp{}
+	if code := kit.CallWait(cactor.NewSingletonPath("game", "leader"), "node", nil, rsp); code != ccode.OK {
+		t.Fatalf("code = %d", code)
+	}
+	return rsp.NodeID
+}
+
+func TestKitSingletonFailover(t *testing.T) {
+	kit := New(t)
+
+	var active1, active2, overlap int32
+	game2 := newSingletonApp(kit.Network(), "game-2", func() *singletonActor {
+		return &singletonActor{active: &active2, overlap: &overlap}
+	})
+	waitActor(t, game2, "leader")
+	if nodeID := callSingletonNode(t, kit); nodeID != "game-2" {
+		t.Fatalf("nodeID = %s", nodeID)
+	}
+
+	// nodeID更小的game-1加入后被选为owner,单例移动到game-1
+	game1 := newSingletonApp(kit.Network(), "game-1", func() *singletonActor {
+		return &singletonActor{active: &active1, overlap: &overlap}
+	})
+	waitActor(t, game1, "leader")
+	waitActorStopped(t, game2, "leader")
+	if nodeID := callSingletonNode(t, kit); nodeID != "game-1" {
+		t.Fatalf("nodeID = %s", nodeID)
+	}
+
+	// owner离开Discovery后由game-2接管,消息路由到新的owner
+	member, _ := kit.Network().Discovery().GetMember("game-1")
+	kit.Network().Discovery().RemoveMember("game-1")
+	waitActor(t, game2, "leader")
+	waitActorStopped(t, game1, "leader")
+	if nodeID := callSingletonNode(t, kit); nodeID != "game-2" {
+		t.Fatalf("nodeID = %s", nodeID)
+	}
+
+	// game-1重新加入后再次成为owner
+	kit.Network().Discovery().AddMember(member)
+	waitActor(t, game1, "leader")
+	waitActorStopped(t, game2, "leader")
+	if nodeID := callSingletonNode(t, kit); nodeID != "game-1" {
+		t.Fatalf("nodeID = %s", nodeID)
+	}
+
+	if atomic.LoadInt32(&overlap) != 0 {
+		t.Fatal("singleton activated twice")
+	}
+}
@@ -117,6 +117,8 @@ func (p *Cluster) localProcess() {
 		message.IsCluster = true
 		message.Session = packet.Session
 		message.Args = packet.ArgBytes
+		message.Priority = packet.Priority
+		message.CallChain = packet.CallChain
 
 		p.app.ActorSystem().PostLocal(&message)
 	}
@@ -166,6 +168,8 @@ func (p *Cluster) remoteProcess() {
 		}
 
 		message.IsCluster = true
+		message.Priority = packet.Priority
+		message.CallChain = packet.CallChain
 		if len(natsMsg.Reply) > 0 {
 			message.ClusterReply = natsMsg
 		}
@@ -115,7 +115,7 @@ func Kick(iActor cfacade.IActor, agentPath, sid string, uid int64, reason interf
 		Close:  closed,
 	}
 
-	iActor.Call(agentPath, KickFuncName, rsp)
+	iActor.CallPriority(agentPath, KickFuncName, rsp)
 
 	clog.Infof("[Kick] agentPath = %s, sid = %s, uid = %d, reason = %+v, closed = %t",
 		agentPath, sid, uid, reason, closed)
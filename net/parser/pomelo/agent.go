@@ -7,7 +7,6 @@ import (
 	"time"
 
 	cnet "github.com/cherry-game/cherry/extend/net"
-	ctime "github.com/cherry-game/cherry/extend/time"
 	cutils "github.com/cherry-game/cherry/extend/utils"
 	cfacade "github.com/cherry-game/cherry/facade"
 	clog "github.com/cherry-game/cherry/logger"
@@ -33,7 +32,7 @@ type (
 		chDie                chan struct{}        // wait for close
 		chPending            chan *pendingMessage // push message queue
 		chWrite              chan []byte          // push bytes queue
-		lastAt               int64                // last heartbeat unix time stamp
+		lastAt               int64                // last heartbeat unix time stamp(系统时间,不受时间偏移影响)
 		onCloseFunc          []OnCloseFunc        // on close agent
 	}
 
@@ -110,7 +109,7 @@ func (a *Agent) Unbind() {
 }
 
 func (a *Agent) SetLastAt() {
-	atomic.StoreInt64(&a.lastAt, ctime.Now().ToSecond())
+	atomic.StoreInt64(&a.lastAt, time.Now().Unix())
 }
 
 func (a *Agent) SendRaw(bytes []byte) {
@@ -170,6 +169,7 @@ func (a *Agent) readChan() {
 }
 
 func (a *Agent) writeChan() {
+	// 心跳检查使用系统时间,GM调整的偏移时间只影响游戏时间
 	ticker := time.NewTicker(cmd.heartbeatTime)
 	defer func() {
 		if clog.PrintLevel(zapcore.DebugLevel) {
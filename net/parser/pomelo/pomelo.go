@@ -5,10 +5,31 @@ import (
 	clog "github.com/cherry-game/cherry/logger"
 	pmessage "github.com/cherry-game/cherry/net/parser/pomelo/message"
 	cproto "github.com/cherry-game/cherry/net/proto"
+	csharding "github.com/cherry-game/cherry/net/sharding"
 )
 
 // DefaultDataRoute 默认的消息路由
 func DefaultDataRoute(agent *Agent, route *pmessage.Route, msg *pmessage.Message) {
+	dataRoute(agent, route, msg, func(session *cproto.Session) (string, bool) {
+		member, found := agent.Discovery().Random(route.NodeType())
+		if !found {
+			return "", false
+		}
+		return member.GetNodeID(), true
+	})
+}
+
+// ShardDataRoute 根据session uid一致性hash选择后端节点的消息路由,同一玩家的消息总是路由到相同的节点
+func ShardDataRoute(sharding *csharding.Component) DataRouteFunc {
+	return func(agent *Agent, route *pmessage.Route, msg *pmessage.Message) {
+		dataRoute(agent, route, msg, func(session *cproto.Session) (string, bool) {
+			return sharding.Locate(route.NodeType(), session.Uid)
+		})
+	}
+}
+
+// dataRoute 消息路由,目标节点类型不是当前节点时,通过selectNode选择后端节点
+func dataRoute(agent *Agent, route *pmessage.Route, msg *pmessage.Message, selectNode func(session *cproto.Session) (string, bool)) {
 	session := BuildSession(agent, msg)
 
 	// current node
@@ -27,13 +48,13 @@ func DefaultDataRoute(agent *Agent, route *pmessage.Route, msg *pmessage.Message
 		return
 	}
 
-	member, found := agent.Discovery().Random(route.NodeType())
+	nodeID, found := selectNode(session)
 	if !found {
 		return
 	}
 
-	targetPath := cfacade.NewPath(member.GetNodeID(), route.HandleName())
-	err := ClusterLocalDataRoute(agent, session, route, msg, member.GetNodeID(), targetPath)
+	targetPath := cfacade.NewPath(nodeID, route.HandleName())
+	err := ClusterLocalDataRoute(agent, session, route, msg, nodeID, targetPath)
 	if err != nil {
 		clog.Warnf("[sid = %s,uid = %d,route = %s] cluster local data error. err= %v",
 			agent.SID(),
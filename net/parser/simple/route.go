@@ -4,6 +4,7 @@ import (
 	cfacade "github.com/cherry-game/cherry/facade"
 	clog "github.com/cherry-game/cherry/logger"
 	cproto "github.com/cherry-game/cherry/net/proto"
+	csharding "github.com/cherry-game/cherry/net/sharding"
 )
 
 var (
@@ -35,6 +36,26 @@ func GetNodeRoute(mid uint32) (*NodeRoute, bool) {
 }
 
 func DefaultDataRoute(agent *Agent, msg *Message, route *NodeRoute) {
+	dataRoute(agent, msg, route, func(session *cproto.Session) (string, bool) {
+		member, found := agent.Discovery().Random(route.NodeType)
+		if !found {
+			return "", false
+		}
+		return member.GetNodeID(), true
+	})
+}
+
+// ShardDataRoute 根据session uid一致性hash选择后端节点的消息路由,同一玩家的消息总是路由到相同的节点
+func ShardDataRoute(sharding *csharding.Component) DataRouteFunc {
+	return func(agent *Agent, msg *Message, route *NodeRoute) {
+		dataRoute(agent, msg, route, func(session *cproto.Session) (string, bool) {
+			return sharding.Locate(route.NodeType, session.Uid)
+		})
+	}
+}
+
+// dataRoute 消息路由,目标节点类型不是当前节点时,通过selectNode选择后端节点
+func dataRoute(agent *Agent, msg *Message, route *NodeRoute, selectNode func(session *cproto.Session) (string, bool)) {
 	session := agent.session
 	session.Mid = msg.MID
 
@@ -54,13 +75,13 @@ func DefaultDataRoute(agent *Agent, msg *Message, route *NodeRoute) {
 		return
 	}
 
-	member, found := agent.Discovery().Random(route.NodeType)
+	nodeID, found := selectNode(session)
 	if !found {
 		return
 	}
 
-	targetPath := cfacade.NewPath(member.GetNodeID(), route.ActorID)
-	ClusterLocalDataRoute(agent, session, msg, route, member.GetNodeID(), targetPath)
+	targetPath := cfacade.NewPath(nodeID, route.ActorID)
+	ClusterLocalDataRoute(agent, session, msg, route, nodeID, targetPath)
 }
 
 func LocalDataRoute(agent *Agent, session *cproto.Session, msg *Message, nodeRoute *NodeRoute, targetPath string) {
@@ -36,6 +36,8 @@ func (x *ClusterPacket) Recycle() {
 	x.FuncName = ""
 	x.ArgBytes = nil
 	x.Session = nil
+	x.Priority = false
+	x.CallChain = nil
 	clusterPacketPool.Put(x)
 }
 
@@ -254,6 +254,8 @@ type ClusterPacket struct {
 	FuncName   string   `protobuf:"bytes,4,opt,name=funcName,proto3" json:"funcName,omitempty"`
 	ArgBytes   []byte   `protobuf:"bytes,5,opt,name=argBytes,proto3" json:"argBytes,omitempty"`
 	Session    *Session `protobuf:"bytes,6,opt,name=session,proto3" json:"session,omitempty"`
+	Priority   bool     `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`  // control message, processed first
+	CallChain  []string `protobuf:"bytes,8,rep,name=callChain,proto3" json:"callChain,omitempty"` // actor paths waiting in the CallWait chain
 }
 
 func (x *ClusterPacket) Reset() {
@@ -330,6 +332,20 @@ func (x *ClusterPacket) GetSession() *Session {
 	return nil
 }
 
+func (x *ClusterPacket) GetPriority() bool {
+	if x != nil {
+		return x.Priority
+	}
+	return false
+}
+
+func (x *ClusterPacket) GetCallChain() []string {
+	if x != nil {
+		return x.CallChain
+	}
+	return nil
+}
+
 type Session struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -417,6 +433,373 @@ func (x *Session) GetData() map[string]string {
 	return nil
 }
 
+// actor location data
+type ActorLocation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorID string `protobuf:"bytes,1,opt,name=actorID,proto3" json:"actorID,omitempty"` // actor id
+	NodeID  string `protobuf:"bytes,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`   // node id
+}
+
+func (x *ActorLocation) Reset() {
+	*x = ActorLocation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActorLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorLocation) ProtoMessage() {}
+
+func (x *ActorLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorLocation.ProtoReflect.Descriptor instead.
+func (*ActorLocation) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ActorLocation) GetActorID() string {
+	if x != nil {
+		return x.ActorID
+	}
+	return ""
+}
+
+func (x *ActorLocation) GetNodeID() string {
+	if x != nil {
+		return x.NodeID
+	}
+	return ""
+}
+
+// cluster event data
+type ClusterEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // event name
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"` // serialized event data
+}
+
+func (x *ClusterEvent) Reset() {
+	*x = ClusterEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClusterEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterEvent) ProtoMessage() {}
+
+func (x *ClusterEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterEvent.ProtoReflect.Descriptor instead.
+func (*ClusterEvent) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ClusterEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ClusterEvent) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// actor introspection request
+type ActorStatRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix   string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`      // actor id prefix
+	Children bool   `protobuf:"varint,2,opt,name=children,proto3" json:"children,omitempty"` // include child actors
+}
+
+func (x *ActorStatRequest) Reset() {
+	*x = ActorStatRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActorStatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorStatRequest) ProtoMessage() {}
+
+func (x *ActorStatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorStatRequest.ProtoReflect.Descriptor instead.
+func (*ActorStatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ActorStatRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ActorStatRequest) GetChildren() bool {
+	if x != nil {
+		return x.Children
+	}
+	return false
+}
+
+// actor runtime stat
+type ActorStat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path          string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                    // actor path
+	State         string   `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`                  // actor state
+	LocalCount    int32    `protobuf:"varint,3,opt,name=localCount,proto3" json:"localCount,omitempty"`       // local mailbox depth
+	RemoteCount   int32    `protobuf:"varint,4,opt,name=remoteCount,proto3" json:"remoteCount,omitempty"`     // remote mailbox depth
+	EventCount    int32    `protobuf:"varint,5,opt,name=eventCount,proto3" json:"eventCount,omitempty"`       // event queue depth
+	PriorityCount int32    `protobuf:"varint,6,opt,name=priorityCount,proto3" json:"priorityCount,omitempty"` // priority lane depth
+	StashCount    int32    `protobuf:"varint,7,opt,name=stashCount,proto3" json:"stashCount,omitempty"`       // stashed message count
+	ChildCount    int32    `protobuf:"varint,8,opt,name=childCount,proto3" json:"childCount,omitempty"`       // child actor count
+	LocalFuncs    []string `protobuf:"bytes,9,rep,name=localFuncs,proto3" json:"localFuncs,omitempty"`        // registered local functions
+	RemoteFuncs   []string `protobuf:"bytes,10,rep,name=remoteFuncs,proto3" json:"remoteFuncs,omitempty"`     // registered remote functions
+	TimerCount    int32    `protobuf:"varint,11,opt,name=timerCount,proto3" json:"timerCount,omitempty"`      // timer count
+	LastAt        int64    `protobuf:"varint,12,opt,name=lastAt,proto3" json:"lastAt,omitempty"`              // last process time (seconds)
+	Invoking      string   `protobuf:"bytes,13,opt,name=invoking,proto3" json:"invoking,omitempty"`           // the function name being invoked
+	Responsive    bool     `protobuf:"varint,14,opt,name=responsive,proto3" json:"responsive,omitempty"`      // actor goroutine responded in time
+}
+
+func (x *ActorStat) Reset() {
+	*x = ActorStat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActorStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorStat) ProtoMessage() {}
+
+func (x *ActorStat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorStat.ProtoReflect.Descriptor instead.
+func (*ActorStat) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ActorStat) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ActorStat) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ActorStat) GetLocalCount() int32 {
+	if x != nil {
+		return x.LocalCount
+	}
+	return 0
+}
+
+func (x *ActorStat) GetRemoteCount() int32 {
+	if x != nil {
+		return x.RemoteCount
+	}
+	return 0
+}
+
+func (x *ActorStat) GetEventCount() int32 {
+	if x != nil {
+		return x.EventCount
+	}
+	return 0
+}
+
+func (x *ActorStat) GetPriorityCount() int32 {
+	if x != nil {
+		return x.PriorityCount
+	}
+	return 0
+}
+
+func (x *ActorStat) GetStashCount() int32 {
+	if x != nil {
+		return x.StashCount
+	}
+	return 0
+}
+
+func (x *ActorStat) GetChildCount() int32 {
+	if x != nil {
+		return x.ChildCount
+	}
+	return 0
+}
+
+func (x *ActorStat) GetLocalFuncs() []string {
+	if x != nil {
+		return x.LocalFuncs
+	}
+	return nil
+}
+
+func (x *ActorStat) GetRemoteFuncs() []string {
+	if x != nil {
+		return x.RemoteFuncs
+	}
+	return nil
+}
+
+func (x *ActorStat) GetTimerCount() int32 {
+	if x != nil {
+		return x.TimerCount
+	}
+	return 0
+}
+
+func (x *ActorStat) GetLastAt() int64 {
+	if x != nil {
+		return x.LastAt
+	}
+	return 0
+}
+
+func (x *ActorStat) GetInvoking() string {
+	if x != nil {
+		return x.Invoking
+	}
+	return ""
+}
+
+func (x *ActorStat) GetResponsive() bool {
+	if x != nil {
+		return x.Responsive
+	}
+	return false
+}
+
+type ActorStatList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List []*ActorStat `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *ActorStatList) Reset() {
+	*x = ActorStatList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActorStatList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorStatList) ProtoMessage() {}
+
+func (x *ActorStatList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorStatList.ProtoReflect.Descriptor instead.
+func (*ActorStatList) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ActorStatList) GetList() []*ActorStat {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
 type PomeloResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -431,7 +814,7 @@ type PomeloResponse struct {
 func (x *PomeloResponse) Reset() {
 	*x = PomeloResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_proto_msgTypes[6]
+		mi := &file_proto_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -444,7 +827,7 @@ func (x *PomeloResponse) String() string {
 func (*PomeloResponse) ProtoMessage() {}
 
 func (x *PomeloResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_proto_msgTypes[6]
+	mi := &file_proto_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,7 +840,7 @@ func (x *PomeloResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PomeloResponse.ProtoReflect.Descriptor instead.
 func (*PomeloResponse) Descriptor() ([]byte, []int) {
-	return file_proto_proto_rawDescGZIP(), []int{6}
+	return file_proto_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PomeloResponse) GetSid() string {
@@ -501,7 +884,7 @@ type PomeloPush struct {
 func (x *PomeloPush) Reset() {
 	*x = PomeloPush{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_proto_msgTypes[7]
+		mi := &file_proto_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -514,7 +897,7 @@ func (x *PomeloPush) String() string {
 func (*PomeloPush) ProtoMessage() {}
 
 func (x *PomeloPush) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_proto_msgTypes[7]
+	mi := &file_proto_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -527,7 +910,7 @@ func (x *PomeloPush) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PomeloPush.ProtoReflect.Descriptor instead.
 func (*PomeloPush) Descriptor() ([]byte, []int) {
-	return file_proto_proto_rawDescGZIP(), []int{7}
+	return file_proto_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PomeloPush) GetSid() string {
@@ -565,7 +948,7 @@ type PomeloKick struct {
 func (x *PomeloKick) Reset() {
 	*x = PomeloKick{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_proto_msgTypes[8]
+		mi := &file_proto_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -578,7 +961,7 @@ func (x *PomeloKick) String() string {
 func (*PomeloKick) ProtoMessage() {}
 
 func (x *PomeloKick) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_proto_msgTypes[8]
+	mi := &file_proto_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +974,7 @@ func (x *PomeloKick) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PomeloKick.ProtoReflect.Descriptor instead.
 func (*PomeloKick) Descriptor() ([]byte, []int) {
-	return file_proto_proto_rawDescGZIP(), []int{8}
+	return file_proto_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PomeloKick) GetSid() string {
@@ -636,7 +1019,7 @@ type PomeloBroadcastPush struct {
 func (x *PomeloBroadcastPush) Reset() {
 	*x = PomeloBroadcastPush{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_proto_msgTypes[9]
+		mi := &file_proto_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -649,7 +1032,7 @@ func (x *PomeloBroadcastPush) String() string {
 func (*PomeloBroadcastPush) ProtoMessage() {}
 
 func (x *PomeloBroadcastPush) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_proto_msgTypes[9]
+	mi := &file_proto_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -662,7 +1045,7 @@ func (x *PomeloBroadcastPush) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PomeloBroadcastPush.ProtoReflect.Descriptor instead.
 func (*PomeloBroadcastPush) Descriptor() ([]byte, []int) {
-	return file_proto_proto_rawDescGZIP(), []int{9}
+	return file_proto_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PomeloBroadcastPush) GetUidList() []int64 {
@@ -719,7 +1102,7 @@ var file_proto_proto_rawDesc = []byte{
 	0x69, 0x73, 0x74, 0x22, 0x32, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
 	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63,
 	0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xd5, 0x01, 0x0a, 0x0d, 0x43, 0x6c, 0x75, 0x73,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x8f, 0x02, 0x0a, 0x0d, 0x43, 0x6c, 0x75, 0x73,
 	0x74, 0x65, 0x72, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x75, 0x69,
 	0x6c, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x75,
 	0x69, 0x6c, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63,
@@ -732,21 +1115,68 @@ var file_proto_proto_rawDesc = []byte{
 	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x61, 0x72, 0x67, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
 	0x2e, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x14, 0x2e, 0x63, 0x68, 0x65, 0x72, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22,
-	0xda, 0x01, 0x0a, 0x07, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x73,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x64, 0x12, 0x10, 0x0a,
-	0x03, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
-	0x1c, 0x0a, 0x09, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x10, 0x0a,
-	0x03, 0x6d, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12,
-	0x32, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
-	0x63, 0x68, 0x65, 0x72, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x1a, 0x37, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5c, 0x0a, 0x0e,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x61, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x61, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x22, 0xda, 0x01, 0x0a, 0x07, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x32, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x68, 0x65, 0x72, 0x72, 0x79,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x37, 0x0a,
+	0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x41, 0x0a, 0x0d, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49,
+	0x44, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x22, 0x36, 0x0a, 0x0c, 0x43, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x22, 0x46, 0x0a, 0x10, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x22, 0xb3, 0x03, 0x0a, 0x09, 0x41, 0x63,
+	0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x70, 0x72, 0x69, 0x6f,
+	0x72, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61,
+	0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73,
+	0x74, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x69,
+	0x6c, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x46, 0x75, 0x6e, 0x63, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x46, 0x75, 0x6e, 0x63, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x74,
+	0x69, 0x6d, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x61, 0x73, 0x74, 0x41, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6c, 0x61, 0x73,
+	0x74, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x76, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x76, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x12,
+	0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x69, 0x76, 0x65, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x69, 0x76, 0x65, 0x22,
+	0x3b, 0x0a, 0x0d, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x2a, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x63, 0x68, 0x65, 0x72, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x74,
+	0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x5c, 0x0a, 0x0e,
 	0x50, 0x6f, 0x6d, 0x65, 0x6c, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10,
 	0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x64,
 	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d,
@@ -789,7 +1219,7 @@ func file_proto_proto_rawDescGZIP() []byte {
 	return file_proto_proto_rawDescData
 }
 
-var file_proto_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_proto_proto_goTypes = []interface{}{
 	(*I32)(nil),                 // 0: cherryProto.I32
 	(*Member)(nil),              // 1: cherryProto.Member
@@ -797,23 +1227,29 @@ var file_proto_proto_goTypes = []interface{}{
 	(*Response)(nil),            // 3: cherryProto.Response
 	(*ClusterPacket)(nil),       // 4: cherryProto.ClusterPacket
 	(*Session)(nil),             // 5: cherryProto.Session
-	(*PomeloResponse)(nil),      // 6: cherryProto.PomeloResponse
-	(*PomeloPush)(nil),          // 7: cherryProto.PomeloPush
-	(*PomeloKick)(nil),          // 8: cherryProto.PomeloKick
-	(*PomeloBroadcastPush)(nil), // 9: cherryProto.PomeloBroadcastPush
-	nil,                         // 10: cherryProto.Member.SettingsEntry
-	nil,                         // 11: cherryProto.Session.DataEntry
+	(*ActorLocation)(nil),       // 6: cherryProto.ActorLocation
+	(*ClusterEvent)(nil),        // 7: cherryProto.ClusterEvent
+	(*ActorStatRequest)(nil),    // 8: cherryProto.ActorStatRequest
+	(*ActorStat)(nil),           // 9: cherryProto.ActorStat
+	(*ActorStatList)(nil),       // 10: cherryProto.ActorStatList
+	(*PomeloResponse)(nil),      // 11: cherryProto.PomeloResponse
+	(*PomeloPush)(nil),          // 12: cherryProto.PomeloPush
+	(*PomeloKick)(nil),          // 13: cherryProto.PomeloKick
+	(*PomeloBroadcastPush)(nil), // 14: cherryProto.PomeloBroadcastPush
+	nil,                         // 15: cherryProto.Member.SettingsEntry
+	nil,                         // 16: cherryProto.Session.DataEntry
 }
 var file_proto_proto_depIdxs = []int32{
-	10, // 0: cherryProto.Member.settings:type_name -> cherryProto.Member.SettingsEntry
+	15, // 0: cherryProto.Member.settings:type_name -> cherryProto.Member.SettingsEntry
 	1,  // 1: cherryProto.MemberList.list:type_name -> cherryProto.Member
 	5,  // 2: cherryProto.ClusterPacket.session:type_name -> cherryProto.Session
-	11, // 3: cherryProto.Session.data:type_name -> cherryProto.Session.DataEntry
-	4,  // [4:4] is the sub-list for method output_type
-	4,  // [4:4] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	16, // 3: cherryProto.Session.data:type_name -> cherryProto.Session.DataEntry
+	9,  // 4: cherryProto.ActorStatList.list:type_name -> cherryProto.ActorStat
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_proto_proto_init() }
@@ -895,7 +1331,7 @@ func file_proto_proto_init() {
 			}
 		}
 		file_proto_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PomeloResponse); i {
+			switch v := v.(*ActorLocation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -907,7 +1343,7 @@ func file_proto_proto_init() {
 			}
 		}
 		file_proto_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PomeloPush); i {
+			switch v := v.(*ClusterEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -919,7 +1355,7 @@ func file_proto_proto_init() {
 			}
 		}
 		file_proto_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PomeloKick); i {
+			switch v := v.(*ActorStatRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -931,6 +1367,66 @@ func file_proto_proto_init() {
 			}
 		}
 		file_proto_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActorStat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActorStatList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PomeloResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PomeloPush); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PomeloKick); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PomeloBroadcastPush); i {
 			case 0:
 				return &v.state
@@ -949,7 +1445,7 @@ func file_proto_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,141 @@
+package cherrySharding
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+)
+
+const (
+	DefaultReplicas = 160 // 每个节点的默认虚拟节点数量
+)
+
+// Ring 一致性hash环
+type Ring struct {
+	replicas int               // 虚拟节点数量
+	hashes   []uint32          // 已排序的虚拟节点hash
+	nodes    map[uint32]string // key:虚拟节点hash, value:nodeID
+	members  map[string]bool   // key:nodeID
+}
+
+func NewRing(replicas int) *Ring {
+	if replicas < 1 {
+		replicas = DefaultReplicas
+	}
+
+	return &Ring{
+		replicas: replicas,
+		nodes:    make(map[uint32]string),
+		members:  make(map[string]bool),
+	}
+}
+
+func hashKey(key string) uint32 {
+	return crc32.ChecksumIEEE([]byte(key))
+}
+
+// Add 添加节点
+func (r *Ring) Add(nodeIDs ...string) {
+	for _, nodeID := range nodeIDs {
+		if r.members[nodeID] {
+			continue
+		}
+
+		r.members[nodeID] = true
+		r.addReplicas(nodeID)
+	}
+
+	sort.Slice(r.hashes, func(i, j int) bool {
+		return r.hashes[i] < r.hashes[j]
+	})
+}
+
+func (r *Ring) addReplicas(nodeID string) {
+	for i := 0; i < r.replicas; i++ {
+		hash := hashKey(strconv.Itoa(i) + "#" + nodeID)
+
+		// 虚拟节点hash冲突时,归属nodeID较小的节点,保证与节点的添加顺序无关
+		if owner, found := r.nodes[hash]; found {
+			if nodeID < owner {
+				r.nodes[hash] = nodeID
+			}
+			continue
+		}
+
+		r.nodes[hash] = nodeID
+		r.hashes = append(r.hashes, hash)
+	}
+}
+
+// Remove 移除节点
+func (r *Ring) Remove(nodeID string) {
+	if !r.members[nodeID] {
+		return
+	}
+
+	delete(r.members, nodeID)
+
+	// 重建虚拟节点,恢复与该节点hash冲突而被覆盖的虚拟节点
+	r.nodes = make(map[uint32]string)
+	r.hashes = make([]uint32, 0, len(r.hashes))
+	for member := range r.members {
+		r.addReplicas(member)
+	}
+
+	sort.Slice(r.hashes, func(i, j int) bool {
+		return r.hashes[i] < r.hashes[j]
+	})
+}
+
+// clone 复制hash环
+func (r *Ring) clone() *Ring {
+	ring := NewRing(r.replicas)
+	ring.hashes = append(ring.hashes, r.hashes...)
+	for hash, nodeID := range r.nodes {
+		ring.nodes[hash] = nodeID
+	}
+	for nodeID := range r.members {
+		ring.members[nodeID] = true
+	}
+
+	return ring
+}
+
+// Get 获取key所在的节点
+func (r *Ring) Get(key string) (string, bool) {
+	if len(r.hashes) < 1 {
+		return "", false
+	}
+
+	hash := hashKey(key)
+	idx := sort.Search(len(r.hashes), func(i int) bool {
+		return r.hashes[i] >= hash
+	})
+
+	if idx == len(r.hashes) {
+		idx = 0
+	}
+
+	return r.nodes[r.hashes[idx]], true
+}
+
+// Contains 是否包含节点
+func (r *Ring) Contains(nodeID string) bool {
+	return r.members[nodeID]
+}
+
+// Members 节点列表
+func (r *Ring) Members() []string {
+	list := make([]string, 0, len(r.members))
+	for nodeID := range r.members {
+		list = append(list, nodeID)
+	}
+
+	sort.Strings(list)
+	return list
+}
+
+// Len 节点数量
+func (r *Ring) Len() int {
+	return len(r.members)
+}
@@ -0,0 +1,74 @@
+package cherrySharding
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestRingGet(t *testing.T) {
+	ring := NewRing(0)
+
+	if _, found := ring.Get("1"); found {
+		t.Fatal("empty ring")
+	}
+
+	ring.Add("game-1", "game-2", "game-3")
+
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		nodeID, _ := ring.Get(strconv.Itoa(i))
+		counts[nodeID]++
+	}
+
+	for nodeID, count := range counts {
+		if count < 600 {
+			t.Fatalf("unbalanced. nodeID = %s, count = %d", nodeID, count)
+		}
+	}
+}
+
+func TestRingRemove(t *testing.T) {
+	before := NewRing(0)
+	before.Add("game-1", "game-2", "game-3")
+
+	after := NewRing(0)
+	after.Add("game-1", "game-2", "game-3")
+	after.Remove("game-3")
+
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		from, _ := before.Get(key)
+		to, _ := after.Get(key)
+
+		// 只有离开节点的key发生迁移
+		if from != "game-3" && from != to {
+			t.Fatalf("key = %s moved from %s to %s", key, from, to)
+		}
+	}
+}
+
+func TestRingCollision(t *testing.T) {
+	// "0#game-29685295"与"0#game-32060020"的crc32相同
+	a, b := "game-29685295", "game-32060020"
+
+	ring1 := NewRing(1)
+	ring1.Add(a, b)
+
+	ring2 := NewRing(1)
+	ring2.Add(b, a)
+
+	if len(ring1.hashes) != 1 || len(ring2.hashes) != 1 {
+		t.Fatalf("hashes = %v, %v", ring1.hashes, ring2.hashes)
+	}
+
+	node1, _ := ring1.Get("1")
+	node2, _ := ring2.Get("1")
+	if node1 != node2 {
+		t.Fatalf("node1 = %s, node2 = %s", node1, node2)
+	}
+
+	ring1.Remove(node1)
+	if nodeID, found := ring1.Get("1"); !found || nodeID == node1 {
+		t.Fatalf("nodeID = %s", nodeID)
+	}
+}
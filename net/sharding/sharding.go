@@ -0,0 +1,222 @@
+// Package cherrySharding 一致性hash分片,将实体key(uid、公会id、房间id等)映射到指定类型的节点
+package cherrySharding
+
+import (
+	"sync"
+
+	cstring "github.com/cherry-game/cherry/extend/string"
+	cutils "github.com/cherry-game/cherry/extend/utils"
+	cfacade "github.com/cherry-game/cherry/facade"
+	clog "github.com/cherry-game/cherry/logger"
+)
+
+const (
+	Name = "sharding_component"
+)
+
+type (
+	Component struct {
+		cfacade.Component
+		sync.RWMutex
+		rebalanceLock sync.Mutex       // 串行执行分片变化,保证每次变化基于上一次的结果
+		replicas      int              // 每个节点的虚拟节点数量
+		rings         map[string]*Ring // key:nodeType, value:*Ring
+		listeners     []RebalanceFunc  // 分片变化监听函数
+	}
+
+	// Rebalance 节点加入或离开时的分片变化信息
+	Rebalance struct {
+		NodeType string          // 节点类型
+		Member   cfacade.IMember // 加入或离开的节点
+		Joined   bool            // true:加入,false:离开
+		Before   *Ring           // 变化前的hash环
+		After    *Ring           // 变化后的hash环
+	}
+
+	// RebalanceFunc 分片变化监听函数,在hash环切换前同步执行(actor迁移完成后才切换hash环)
+	RebalanceFunc func(rebalance *Rebalance)
+
+	// IHandoff 分片变化时迁移本节点actor(cherryActor.System实现了该接口)
+	IHandoff interface {
+		Handoff(nodeType string, locate func(key string) (string, bool))
+	}
+)
+
+func New(replicas ...int) *Component {
+	component := &Component{
+		replicas: DefaultReplicas,
+		rings:    make(map[string]*Ring),
+	}
+
+	if len(replicas) > 0 && replicas[0] > 0 {
+		component.replicas = replicas[0]
+	}
+
+	return component
+}
+
+func (*Component) Name() string {
+	return Name
+}
+
+func (p *Component) Init() {
+	discovery := p.App().Discovery()
+	if discovery == nil {
+		clog.Warn("[sharding] Discovery is nil.")
+		return
+	}
+
+	discovery.OnAddMember(func(member cfacade.IMember) {
+		p.rebalance(member, true)
+	})
+
+	discovery.OnRemoveMember(func(member cfacade.IMember) {
+		p.rebalance(member, false)
+	})
+
+	// 分片变化时,通知本节点的actor进行迁移
+	if handoff, ok := p.App().ActorSystem().(IHandoff); ok {
+		p.OnRebalance(func(rebalance *Rebalance) {
+			handoff.Handoff(rebalance.NodeType, rebalance.After.Get)
+		})
+	}
+}
+
+// OnRebalance 添加分片变化监听函数
+func (p *Component) OnRebalance(fn RebalanceFunc) {
+	if fn == nil {
+		return
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	p.listeners = append(p.listeners, fn)
+}
+
+// Locate 获取key所在的节点id,未设置discovery时返回false
+func (p *Component) Locate(nodeType string, key interface{}) (string, bool) {
+	ring, found := p.ring(nodeType)
+	if !found {
+		return "", false
+	}
+
+	return ring.Get(cstring.ToString(key))
+}
+
+// LocateMember 获取key所在的节点
+func (p *Component) LocateMember(nodeType string, key interface{}) (cfacade.IMember, bool) {
+	nodeID, found := p.Locate(nodeType, key)
+	if !found {
+		return nil, false
+	}
+
+	discovery := p.discovery()
+	if discovery == nil {
+		return nil, false
+	}
+
+	return discovery.GetMember(nodeID)
+}
+
+// discovery 获取当前节点的discovery,未设置时返回nil
+func (p *Component) discovery() cfacade.IDiscovery {
+	if p.App() == nil {
+		return nil
+	}
+
+	return p.App().Discovery()
+}
+
+// ring 获取节点类型的hash环,不存在时根据discovery的成员列表创建
+func (p *Component) ring(nodeType string) (*Ring, bool) {
+	p.RLock()
+	ring, found := p.rings[nodeType]
+	p.RUnlock()
+
+	if found {
+		return ring, true
+	}
+
+	discovery := p.discovery()
+	if discovery == nil {
+		clog.Warnf("[sharding] Discovery is nil. [nodeType = %s]", nodeType)
+		return nil, false
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if ring, found = p.rings[nodeType]; found {
+		return ring, true
+	}
+
+	ring = NewRing(p.replicas)
+	for _, member := range discovery.ListByType(nodeType) {
+		ring.Add(member.GetNodeID())
+	}
+	p.rings[nodeType] = ring
+
+	return ring, true
+}
+
+func (p *Component) rebalance(member cfacade.IMember, joined bool) {
+	nodeType := member.GetNodeType()
+
+	// 监听函数在锁外执行(可调用Locate),通过rebalanceLock避免并发的分片变化互相覆盖
+	p.rebalanceLock.Lock()
+	defer p.rebalanceLock.Unlock()
+
+	p.Lock()
+	before, found := p.rings[nodeType]
+	if !found {
+		// 该类型的hash环未被使用过,无需迁移
+		p.Unlock()
+		return
+	}
+
+	after := before.clone()
+	if joined {
+		after.Add(member.GetNodeID())
+	} else {
+		after.Remove(member.GetNodeID())
+	}
+
+	listeners := p.listeners
+	p.Unlock()
+
+	rebalance := &Rebalance{
+		NodeType: nodeType,
+		Member:   member,
+		Joined:   joined,
+		Before:   before,
+		After:    after,
+	}
+
+	for _, listener := range listeners {
+		cutils.Try(func() {
+			listener(rebalance)
+		}, func(errString string) {
+			clog.Warnf("[sharding] Rebalance listener error. [nodeType = %s, err = %s]", nodeType, errString)
+		})
+	}
+
+	p.Lock()
+	p.rings[nodeType] = after
+	p.Unlock()
+
+	clog.Infof("[sharding] Rebalance. [nodeType = %s, nodeID = %s, joined = %v, members = %v]",
+		nodeType,
+		member.GetNodeID(),
+		joined,
+		after.Members(),
+	)
+}
+
+// Moved 判断key是否迁移到其他节点
+func (p *Rebalance) Moved(key interface{}) (from string, to string, moved bool) {
+	k := cstring.ToString(key)
+	from, _ = p.Before.Get(k)
+	to, _ = p.After.Get(k)
+	return from, to, from != to
+}
@@ -0,0 +1,78 @@
+package cherrySharding
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	cproto "github.com/cherry-game/cherry/net/proto"
+)
+
+func TestRebalanceConcurrent(t *testing.T) {
+	component := New()
+	component.rings["game"] = NewRing(0)
+	component.rings["game"].Add("game-1")
+
+	component.OnRebalance(func(rebalance *Rebalance) {
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	var wg sync.WaitGroup
+	for _, nodeID := range []string{"game-2", "game-3", "game-4"} {
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+			component.rebalance(&cproto.Member{NodeID: nodeID, NodeType: "game"}, true)
+		}(nodeID)
+	}
+	wg.Wait()
+
+	if ring := component.rings["game"]; ring.Len() != 4 {
+		t.Fatalf("members = %v", ring.Members())
+	}
+}
+
+func TestLocateWithoutDiscovery(t *testing.T) {
+	component := New()
+
+	if nodeID, found := component.Locate("game", 1001); found {
+		t.Fatalf("nodeID = %s", nodeID)
+	}
+
+	if _, found := component.LocateMember("game", 1001); found {
+		t.Fatal("member found")
+	}
+}
+
+func TestRebalanceSwapAfterListeners(t *testing.T) {
+	component := New()
+	component.rings["game"] = NewRing(0)
+	component.rings["game"].Add("game-1")
+
+	// 监听函数(迁移)执行期间,消息仍路由到旧的节点
+	var (
+		key   int
+		to    string
+		moved bool
+	)
+	component.OnRebalance(func(rebalance *Rebalance) {
+		for key = 0; key < 1000; key++ {
+			var from string
+			if from, to, moved = rebalance.Moved(key); moved {
+				if nodeID, _ := component.Locate("game", key); nodeID != from {
+					t.Errorf("nodeID = %s, from = %s", nodeID, from)
+				}
+				return
+			}
+		}
+	})
+	component.rebalance(&cproto.Member{NodeID: "game-2", NodeType: "game"}, true)
+
+	if !moved {
+		t.Fatal("no key moved")
+	}
+
+	if nodeID, _ := component.Locate("game", key); nodeID != to {
+		t.Fatalf("nodeID = %s, to = %s", nodeID, to)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	cfile "github.com/cherry-game/cherry/extend/file"
 	cjson "github.com/cherry-game/cherry/extend/json"
 	cstring "github.com/cherry-game/cherry/extend/string"
+	ctime "github.com/cherry-game/cherry/extend/time"
 	cfacade "github.com/cherry-game/cherry/facade"
 )
 
@@ -18,6 +19,7 @@ var (
 		env         string  // env name
 		debug       bool    // debug default is true
 		printLevel  string  // cherry log print level
+		timeZone    string  // time zone name, e.g. Asia/Shanghai
 	}{}
 )
 
@@ -41,6 +43,10 @@ func PrintLevel() string {
 	return cfg.printLevel
 }
 
+func TimeZone() string {
+	return cfg.timeZone
+}
+
 func Init(filePath, nodeID string) (cfacade.INode, error) {
 	if filePath == "" {
 		return nil, cerror.Error("File path is nil.")
@@ -73,6 +79,13 @@ func Init(filePath, nodeID string) (cfacade.INode, error) {
 	cfg.env = jsonConfig.GetString("env", "default")
 	cfg.debug = jsonConfig.GetBool("debug", true)
 	cfg.printLevel = jsonConfig.GetString("print_level", "debug")
+	cfg.timeZone = jsonConfig.GetString("time_zone")
+
+	if cfg.timeZone != "" {
+		if err = ctime.SetOffsetLocation(cfg.timeZone); err != nil {
+			return nil, cerror.Errorf("Time zone error. [timeZone = %s, err = %v]", cfg.timeZone, err)
+		}
+	}
 
 	return node, nil
 }